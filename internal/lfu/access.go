@@ -0,0 +1,40 @@
+package lfu
+
+import "errors"
+
+// GetOrDefault returns the value of the key like Get, or def if the key is not present.
+// A miss does not insert def into the cache.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) GetOrDefault(key K, def V) V {
+	value, err := l.Get(key)
+	if err != nil {
+		return def
+	}
+
+	return value
+}
+
+// LoadOrStore returns the value of the key like Get on a hit. On a miss it calls loader
+// and, if loader succeeds, stores and returns the loaded value. If loader fails,
+// nothing is stored and its error is returned, so the next call loads again.
+//
+// A live marker stored by PutNegative is a hit: ErrNegativeCached is returned without
+// calling loader. To cache failures, call PutNegative after LoadOrStore returns the error.
+//
+// O(1), not amortized, plus the cost of loader on a miss
+func (l *cacheImpl[K, V]) LoadOrStore(key K, loader func() (V, error)) (V, error) {
+	value, err := l.Get(key)
+	if !errors.Is(err, ErrKeyNotFound) {
+		return value, err
+	}
+
+	value, err = loader()
+	if err != nil {
+		return value, err
+	}
+
+	l.Put(key, value)
+
+	return value, nil
+}
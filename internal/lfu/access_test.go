@@ -0,0 +1,71 @@
+package lfu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrDefault(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+
+	require.Equal(t, 1, cache.GetOrDefault("a", -1))
+	require.Equal(t, -1, cache.GetOrDefault("b", -1))
+	require.Equal(t, 1, cache.Size())
+
+	_, err := cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+}
+
+func TestLoadOrStore(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+
+	loads := 0
+	loader := func(value int, err error) func() (int, error) {
+		return func() (int, error) {
+			loads++
+			return value, err
+		}
+	}
+
+	value, err := cache.LoadOrStore("a", loader(10, nil))
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+	require.Zero(t, loads)
+
+	value, err = cache.LoadOrStore("b", loader(2, nil))
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+	require.Equal(t, 1, loads)
+
+	value, err = cache.LoadOrStore("b", loader(20, nil))
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+	require.Equal(t, 1, loads)
+
+	upstreamErr := errors.New("upstream unavailable")
+
+	_, err = cache.LoadOrStore("c", loader(0, upstreamErr))
+	require.ErrorIs(t, err, upstreamErr)
+	require.Equal(t, 2, loads)
+
+	_, err = cache.Get("c")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	cache.PutNegative("c", 0)
+
+	_, err = cache.LoadOrStore("c", loader(3, nil))
+	require.ErrorIs(t, err, ErrNegativeCached)
+	require.Equal(t, 2, loads)
+}
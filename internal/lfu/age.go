@@ -0,0 +1,50 @@
+package lfu
+
+import "time"
+
+// AgeStats aggregates the time elapsed since the last access of the entries.
+type AgeStats struct {
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+}
+
+// WithAccessTimes enables tracking of the last access time of every entry according to
+// the cache clock, see WithClock. Both insertion and access count, like for WithGlobalRecency.
+// It costs a clock reading on every access.
+func WithAccessTimes[K comparable, V any]() Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.trackAccessTimes = true
+	}
+}
+
+// AgeStats returns the minimum, maximum and mean time elapsed since the last access of the entries,
+// relative to the current time of the cache clock, e.g. to choose an idle eviction threshold.
+// Frequencies are not changed.
+//
+// Requires WithAccessTimes, otherwise returns zero stats. Returns zero stats for an empty cache.
+//
+// O(size)
+func (l *cacheImpl[K, V]) AgeStats() AgeStats {
+	if !l.trackAccessTimes || l.Size() == 0 {
+		return AgeStats{}
+	}
+
+	now := l.now().UnixNano()
+	stats := AgeStats{Min: time.Duration(now - l.first().Value.accessedAt)}
+
+	// The sum of ages may overflow int64 for large caches of old entries.
+	var sum float64
+
+	for node := l.first(); node != nil; node = l.successor(node) {
+		age := time.Duration(now - node.Value.accessedAt)
+
+		stats.Min = min(stats.Min, age)
+		stats.Max = max(stats.Max, age)
+		sum += float64(age)
+	}
+
+	stats.Mean = time.Duration(sum / float64(l.Size()))
+
+	return stats
+}
@@ -0,0 +1,47 @@
+package lfu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgeStats(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(4, WithClock[string, int](clock.Now), WithAccessTimes[string, int]())
+	require.Equal(t, AgeStats{}, cache.AgeStats())
+
+	cache.Put("a", 1)
+	clock.Advance(10 * time.Second)
+	cache.Put("b", 2)
+	clock.Advance(10 * time.Second)
+	cache.Put("c", 3)
+	clock.Advance(5 * time.Second)
+
+	// a: 25s, b: 15s, c: 5s.
+	require.Equal(t, AgeStats{Min: 5 * time.Second, Max: 25 * time.Second, Mean: 15 * time.Second}, cache.AgeStats())
+
+	_, _ = cache.Get("a")
+	clock.Advance(time.Second)
+
+	// a: 1s, b: 16s, c: 6s.
+	require.Equal(t, AgeStats{Min: time.Second, Max: 16 * time.Second, Mean: 23 * time.Second / 3}, cache.AgeStats())
+
+	clone := cache.CloneWith(func(value int) int { return value })
+	require.Equal(t, cache.AgeStats(), clone.AgeStats())
+}
+
+func TestAgeStatsRequiresAccessTimes(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.Put(1, 1)
+	clock.Advance(time.Minute)
+
+	require.Equal(t, AgeStats{}, cache.AgeStats())
+}
@@ -0,0 +1,127 @@
+package lfu
+
+import (
+	"iter"
+	"sync"
+)
+
+// bufferedWrite is a pending Put or, if flushed is not nil, a Flush marker
+type bufferedWrite[K comparable, V any] struct {
+	key     K
+	value   V
+	flushed chan struct{}
+}
+
+// bufferedCache represents LFU cache safe for concurrent use that applies writes asynchronously
+type bufferedCache[K comparable, V any] struct {
+	cache  *synchronizedCache[K, V]
+	writes chan bufferedWrite[K, V]
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewBuffered initializes the cache safe for concurrent use with the given capacity
+// where Put only enqueues the write into a buffer of bufferSize writes. A background
+// goroutine applies queued writes in batches, taking the lock once per batch.
+// Put blocks only while the buffer is full.
+//
+// Reads do not wait for pending writes: Get, All and other reads may miss up to
+// 2*bufferSize+1 writes, those in the buffer plus a batch of up to bufferSize+1 writes
+// already taken from it but not applied yet. Call Flush to make all previous writes
+// visible. Close must be called to stop the background goroutine.
+func NewBuffered[K comparable, V any](capacity, bufferSize int) *bufferedCache[K, V] {
+	c := &bufferedCache[K, V]{
+		cache:  NewSynchronized[K, V](capacity),
+		writes: make(chan bufferedWrite[K, V], bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go c.drain()
+
+	return c
+}
+
+func (c *bufferedCache[K, V]) Get(key K) (V, error) {
+	return c.cache.Get(key)
+}
+
+// Put enqueues the write. Panics if the cache is closed.
+func (c *bufferedCache[K, V]) Put(key K, value V) {
+	c.writes <- bufferedWrite[K, V]{key: key, value: value}
+}
+
+func (c *bufferedCache[K, V]) All() iter.Seq2[K, V] {
+	return c.cache.All()
+}
+
+func (c *bufferedCache[K, V]) Size() int {
+	return c.cache.Size()
+}
+
+func (c *bufferedCache[K, V]) Capacity() int {
+	return c.cache.Capacity()
+}
+
+func (c *bufferedCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	return c.cache.GetKeyFrequency(key)
+}
+
+// Flush blocks until every write enqueued before the call is applied.
+// Panics if the cache is closed.
+func (c *bufferedCache[K, V]) Flush() {
+	flushed := make(chan struct{})
+	c.writes <- bufferedWrite[K, V]{flushed: flushed}
+	<-flushed
+}
+
+// Close applies all pending writes and stops the background goroutine.
+// The cache stays readable, but Put and Flush panic afterwards. Close is idempotent.
+func (c *bufferedCache[K, V]) Close() {
+	c.once.Do(func() {
+		close(c.writes)
+	})
+
+	<-c.done
+}
+
+// drain applies writes until the buffer is closed.
+func (c *bufferedCache[K, V]) drain() {
+	defer close(c.done)
+
+	batch := make([]bufferedWrite[K, V], 0, cap(c.writes)+1)
+
+	for write := range c.writes {
+		batch = append(batch[:0], write)
+
+	collect:
+		for len(batch) < cap(batch) {
+			select {
+			case write, ok := <-c.writes:
+				if !ok {
+					break collect
+				}
+
+				batch = append(batch, write)
+			default:
+				break collect
+			}
+		}
+
+		c.apply(batch)
+	}
+}
+
+// apply performs the batch under a single lock acquisition.
+func (c *bufferedCache[K, V]) apply(batch []bufferedWrite[K, V]) {
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	for _, write := range batch {
+		if write.flushed != nil {
+			close(write.flushed)
+			continue
+		}
+
+		c.cache.cache.Put(write.key, write.value)
+	}
+}
@@ -0,0 +1,71 @@
+package lfu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferedFlush(t *testing.T) {
+	t.Parallel()
+
+	cache := NewBuffered[int, int](100, 8)
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 25; i++ {
+				cache.Put(worker*25+i, i)
+			}
+		}()
+	}
+
+	wg.Wait()
+	cache.Flush()
+
+	require.Equal(t, 100, cache.Size())
+
+	for key := 0; key < 100; key++ {
+		value, err := cache.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, key%25, value)
+	}
+}
+
+func TestBufferedCloseDrains(t *testing.T) {
+	t.Parallel()
+
+	cache := NewBuffered[int, int](10, 100)
+
+	for i := 0; i < 50; i++ {
+		cache.Put(i%5, i)
+	}
+
+	cache.Close()
+	cache.Close()
+
+	require.Equal(t, 5, cache.Size())
+	require.Equal(t, 10, cache.Capacity())
+
+	frequency, err := cache.GetKeyFrequency(0)
+	require.NoError(t, err)
+	require.Equal(t, 10, frequency)
+
+	value, err := cache.Get(4)
+	require.NoError(t, err)
+	require.Equal(t, 49, value)
+
+	keys, _ := collect(cache.All())
+	require.Len(t, keys, 5)
+
+	require.Panics(t, func() {
+		cache.Put(1, 1)
+	})
+}
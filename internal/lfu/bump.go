@@ -0,0 +1,43 @@
+package lfu
+
+// BumpMany increments the frequency of every present key by one, as if each was read by Get,
+// in slice order, so among keys ending up with the same frequency the later ones are more recent.
+// Stats are not affected. Returns the keys that are not present, in slice order.
+//
+// O(len(keys)), not amortized
+func (l *cacheImpl[K, V]) BumpMany(keys []K) (missing []K) {
+	defer l.debugVerify()
+
+	for _, key := range keys {
+		node, ok := l.index[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+
+		l.touch(node)
+	}
+
+	return missing
+}
+
+// WarmFromAccessLog replays an access log, oldest access first, to reconstruct realistic
+// frequencies and recency: the first appearance of a key inserts it with its value from values,
+// evicting as Put does, and every following appearance bumps it as BumpMany does.
+// Keys without a value in values are skipped. Hit and miss stats are not affected.
+//
+// O(len(keys)), not amortized, unless there are pinned entries or an eviction veto is set
+func (l *cacheImpl[K, V]) WarmFromAccessLog(keys []K, values map[K]V) {
+	defer l.debugVerify()
+
+	for _, key := range keys {
+		if node, ok := l.index[key]; ok {
+			l.touch(node)
+			continue
+		}
+
+		if value, ok := values[key]; ok {
+			l.TryPut(key, value)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpMany(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	cache.Put(4, 40)
+	_, _ = cache.Get(4)
+
+	before := cache.FrequenciesOf([]int{1, 2, 3, 4})
+
+	missing := cache.BumpMany([]int{3, 5, 1, 2, 6})
+	require.Equal(t, []int{5, 6}, missing)
+
+	after := cache.FrequenciesOf([]int{1, 2, 3, 4})
+	require.Equal(t, before[1]+1, after[1])
+	require.Equal(t, before[2]+1, after[2])
+	require.Equal(t, before[3]+1, after[3])
+	require.Equal(t, before[4], after[4])
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{2, 1, 3, 4}, keys)
+	require.Equal(t, Stats{Hits: 1}, cache.Stats())
+}
+
+func TestBumpManyNothingMissing(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+	cache.Put(1, 10)
+
+	require.Nil(t, cache.BumpMany([]int{1}))
+}
+
+func TestWarmFromAccessLog(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	cache.WarmFromAccessLog(
+		[]string{"a", "b", "a", "c", "x", "b", "a", "d", "c"},
+		map[string]int{"a": 1, "b": 2, "c": 3, "d": 4},
+	)
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	require.Equal(t, []int{1, 2, 3}, values)
+	// "d" evicts "c" and is evicted by it in turn, so "c" starts over at frequency 1.
+	require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, cache.FrequenciesOf(keys))
+	require.Equal(t, Stats{Evictions: 2}, cache.Stats())
+}
@@ -0,0 +1,163 @@
+package lfu
+
+import (
+	"errors"
+
+	"lfucache/internal/linkedlist"
+)
+
+var (
+	// ErrCacheFull is returned by PutChecked when a new key does not fit and nothing may be evicted.
+	ErrCacheFull = errors.New("cache is full")
+	// ErrAllPinned is returned by PutChecked when a new key does not fit and every entry is pinned.
+	ErrAllPinned = errors.New("cache is full and every entry is pinned")
+)
+
+// WithSoftCapacity lets the cache grow beyond its capacity up to hard entries
+// without evicting in Put. Once the size exceeds the capacity, the cache is over its
+// soft capacity until ReconcileCapacity evicts the excess.
+//
+// This defers eviction work out of bursts of Put calls at the cost of holding
+// up to hard entries in memory. A hard limit lower than the capacity is ignored.
+func WithSoftCapacity[K comparable, V any](hard int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.hardCapacity = hard
+	}
+}
+
+// WithRejectOnFull makes the full cache reject new keys instead of evicting:
+// Put drops them, TryPut returns false and PutChecked returns ErrCacheFull.
+// Present keys are still updated. Shrinking by SetCapacity still evicts.
+func WithRejectOnFull[K comparable, V any]() Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.rejectOnFull = true
+	}
+}
+
+// WithOnResize sets a callback called after SetCapacity completes, with the previous
+// and the new capacity and the number of entries evicted because of shrinking.
+// The callback must not modify the cache.
+func WithOnResize[K comparable, V any](onResize func(oldCapacity, newCapacity, evicted int)) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.onResize = onResize
+	}
+}
+
+// OverCapacity reports whether the size exceeds the capacity, see WithSoftCapacity.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) OverCapacity() bool {
+	return l.Size() > l.Capacity()
+}
+
+// ReconcileCapacity evicts entries in the usual eviction order until the size
+// does not exceed the capacity and returns the number of evicted entries.
+// Pinned and vetoed entries are not evicted, so the cache may remain over capacity.
+//
+// O(number of evicted entries), not amortized, unless there are pinned entries or an eviction veto is set
+func (l *cacheImpl[K, V]) ReconcileCapacity() int {
+	defer l.debugVerify()
+
+	evicted := 0
+
+	for l.OverCapacity() {
+		victim := l.victim()
+		if victim == nil {
+			break
+		}
+
+		l.evict(victim)
+		evicted++
+	}
+
+	return evicted
+}
+
+// limit returns the size at which Put has to evict.
+func (l *cacheImpl[K, V]) limit() int {
+	return max(l.capacity, l.hardCapacity)
+}
+
+// TrimToSize rebuilds the internal index sized for the current number of entries,
+// releasing the memory the index kept after shrinking from a much larger size
+// (Go maps never shrink on their own). Entries, frequencies and recency are not changed.
+//
+// O(size)
+func (l *cacheImpl[K, V]) TrimToSize() {
+	index := make(map[K]*linkedlist.Node[cacheData[K, V]], l.Size())
+	for key, node := range l.index {
+		index[key] = node
+	}
+
+	l.index = index
+
+	if l.pinned != nil {
+		pinned := make(map[K]struct{}, len(l.pinned))
+		for key := range l.pinned {
+			pinned[key] = struct{}{}
+		}
+
+		l.pinned = pinned
+	}
+}
+
+// SetCapacity changes the capacity of the cache. When shrinking, entries are evicted
+// in the usual eviction order until the size fits the new capacity; pinned and vetoed
+// entries are not evicted, so the cache may remain over capacity.
+//
+// Panics if the capacity is negative.
+//
+// O(number of evicted entries), not amortized, unless there are pinned entries or an eviction veto is set
+func (l *cacheImpl[K, V]) SetCapacity(capacity int) {
+	if capacity < 0 {
+		panic("lfu: capacity must not be negative")
+	}
+
+	oldCapacity := l.capacity
+	l.capacity = capacity
+	evicted := l.ReconcileCapacity()
+
+	if l.onResize != nil {
+		l.onResize(oldCapacity, capacity, evicted)
+	}
+}
+
+// SurvivorsAfterResize returns the keys that SetCapacity(capacity) would keep, in the same
+// order as All, without changing the cache: all but the Size()-capacity first entries in eviction
+// order, see PreviewEvictions. Pinned and vetoed entries always survive.
+//
+// Panics if the capacity is negative.
+//
+// O(size)
+func (l *cacheImpl[K, V]) SurvivorsAfterResize(capacity int) []K {
+	if capacity < 0 {
+		panic("lfu: capacity must not be negative")
+	}
+
+	evicted := make(map[K]struct{}, max(0, l.Size()-capacity))
+
+	for node := range l.evictionOrder() {
+		if len(evicted) >= l.Size()-capacity {
+			break
+		}
+
+		evicted[node.Value.key] = struct{}{}
+	}
+
+	survivors := make([]K, 0, l.Size()-len(evicted))
+
+	for key := range l.All() {
+		if _, ok := evicted[key]; !ok {
+			survivors = append(survivors, key)
+		}
+	}
+
+	return survivors
+}
+
+// Stat returns the size and the capacity of the cache.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Stat() (size, capacity int) {
+	return l.Size(), l.Capacity()
+}
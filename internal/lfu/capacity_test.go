@@ -0,0 +1,218 @@
+package lfu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftCapacity(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithSoftCapacity[int, int](5))
+
+	for i := 1; i <= 5; i++ {
+		cache.Put(i, i*10)
+	}
+
+	require.Equal(t, 5, cache.Size())
+	require.True(t, cache.OverCapacity())
+	require.Equal(t, uint64(0), cache.Stats().Evictions)
+
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(3)
+	_, _ = cache.Get(5)
+
+	cache.Put(6, 60)
+	require.Equal(t, 5, cache.Size())
+
+	_, err := cache.GetKeyFrequency(2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.Equal(t, 2, cache.ReconcileCapacity())
+	require.False(t, cache.OverCapacity())
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{5, 3, 1}, keys)
+	require.Equal(t, uint64(3), cache.Stats().Evictions)
+	require.Equal(t, 0, cache.ReconcileCapacity())
+}
+
+func TestSoftCapacityLowerThanCapacity(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithSoftCapacity[int, int](1))
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+
+	require.Equal(t, 2, cache.Size())
+	require.False(t, cache.OverCapacity())
+}
+
+func TestTrimToSize(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](10_000)
+
+	for i := 0; i < 10_000; i++ {
+		cache.Put(i, i)
+	}
+
+	for i := 0; i < 100; i++ {
+		_, _ = cache.Get(i)
+	}
+
+	removed := make([]int, 0, 9_900)
+	for i := 100; i < 10_000; i++ {
+		removed = append(removed, i)
+	}
+
+	require.Equal(t, 9_900, cache.RemoveMany(removed))
+	require.NoError(t, cache.Pin(0))
+
+	keys, values := collect(cache.All())
+
+	cache.TrimToSize()
+
+	trimmedKeys, trimmedValues := collect(cache.All())
+	require.Equal(t, keys, trimmedKeys)
+	require.Equal(t, values, trimmedValues)
+	require.True(t, cache.IsPinned(0))
+
+	for i := 0; i < 100; i++ {
+		value, err := cache.Get(i)
+		require.NoError(t, err)
+		require.Equal(t, i, value)
+	}
+
+	cache.Put(-1, -1)
+	require.Equal(t, 101, cache.Size())
+
+	frequency, err := cache.GetKeyFrequency(5)
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+}
+
+func TestSetCapacity(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+
+	for i := 1; i <= 4; i++ {
+		cache.Put(i, i*10)
+	}
+
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(4)
+
+	cache.SetCapacity(2)
+
+	size, capacity := cache.Stat()
+	require.Equal(t, 2, size)
+	require.Equal(t, 2, capacity)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{4, 1}, keys)
+
+	cache.SetCapacity(3)
+	cache.Put(5, 50)
+	require.Equal(t, 3, cache.Size())
+
+	require.Panics(t, func() {
+		cache.SetCapacity(-1)
+	})
+}
+
+func TestOnResize(t *testing.T) {
+	t.Parallel()
+
+	type resize struct {
+		oldCapacity, newCapacity, evicted int
+	}
+
+	var resizes []resize
+
+	cache := NewWithOptions(5, WithOnResize[int, int](func(oldCapacity, newCapacity, evicted int) {
+		resizes = append(resizes, resize{oldCapacity, newCapacity, evicted})
+	}))
+
+	for i := range 5 {
+		cache.Put(i, i)
+	}
+
+	cache.SetCapacity(2)
+	cache.SetCapacity(10)
+	cache.SetCapacity(10)
+
+	require.Equal(t, []resize{{5, 2, 3}, {2, 10, 0}, {10, 10, 0}}, resizes)
+	require.Equal(t, 2, cache.Size())
+}
+
+func TestPutCheckedErrors(t *testing.T) {
+	t.Parallel()
+
+	rejecting := NewWithOptions(2, WithRejectOnFull[string, int]())
+	require.NoError(t, rejecting.PutChecked("a", 1))
+	require.NoError(t, rejecting.PutChecked("b", 2))
+	require.ErrorIs(t, rejecting.PutChecked("c", 3), ErrCacheFull)
+	require.NoError(t, rejecting.PutChecked("a", 10))
+	require.False(t, rejecting.TryPut("c", 3))
+
+	keys, _ := collect(rejecting.All())
+	require.Equal(t, []string{"a", "b"}, keys)
+
+	pinned := New[string, int](2)
+	pinned.Put("a", 1)
+	pinned.Put("b", 2)
+	require.NoError(t, pinned.Pin("a"))
+	require.NoError(t, pinned.PutChecked("c", 3))
+	require.NoError(t, pinned.Pin("c"))
+
+	err := pinned.PutChecked("d", 4)
+	require.ErrorIs(t, err, ErrAllPinned)
+	require.NotErrorIs(t, err, ErrCacheFull)
+
+	vetoed := NewWithOptions(1, WithEvictionVeto(func(string, int) bool { return true }))
+	vetoed.Put("a", 1)
+	require.ErrorIs(t, vetoed.PutChecked("b", 2), ErrCacheFull)
+
+	require.ErrorIs(t, New[string, int](0).PutChecked("a", 1), ErrCacheFull)
+}
+
+func TestSurvivorsAfterResize(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](8)
+
+	for i := range 8 {
+		cache.Put(i, i)
+
+		for range (i * 5) % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	allKeys, _ := collect(cache.All())
+
+	for capacity := 0; capacity <= 10; capacity++ {
+		survivors := cache.SurvivorsAfterResize(capacity)
+		require.Len(t, survivors, min(capacity, cache.Size()))
+
+		keys := slices.Clone(survivors)
+		for _, entry := range cache.PreviewEvictions(cache.Size() - capacity) {
+			keys = append(keys, entry.Key)
+		}
+
+		require.ElementsMatch(t, allKeys, keys)
+	}
+
+	survivors := cache.SurvivorsAfterResize(3)
+
+	cache.SetCapacity(3)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, survivors, keys)
+}
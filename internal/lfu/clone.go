@@ -0,0 +1,49 @@
+package lfu
+
+// CloneWith returns an independent copy of the cache with copyValue applied to every value.
+// Pass a deep-copying function for mutable values, or an identity function for a shallow copy.
+//
+// The copy has the same capacity, options, frequencies, recency order, pins, expiration
+// deadlines, access times, versions and stats. The recorder set by WithRecorder is not copied,
+// since both caches writing to it would produce a trace that cannot be replayed.
+//
+// O(size)
+func (l *cacheImpl[K, V]) CloneWith(copyValue func(V) V) *cacheImpl[K, V] {
+	clone := *l
+	clone.recorder = nil
+	clone.reset()
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			copied := clone.insertWithFrequency(node.Value.key, copyValue(node.Value.value), container.Value.freq)
+
+			copied.Value.expiresAt = node.Value.expiresAt
+			copied.Value.accessedAt = node.Value.accessedAt
+			copied.Value.version = node.Value.version
+			copied.Value.hits = node.Value.hits
+			copied.Value.insertedAt = node.Value.insertedAt
+			copied.Value.negative = node.Value.negative
+			copied.Value.reserved = node.Value.reserved
+		}
+	}
+
+	clone.version = l.version
+	clone.insertions = l.insertions
+
+	if l.pinned != nil {
+		clone.pinned = make(map[K]struct{}, len(l.pinned))
+		for key := range l.pinned {
+			clone.pinned[key] = struct{}{}
+		}
+	}
+
+	if l.recency != nil {
+		for key := range l.recency.All() {
+			node := clone.index[key]
+			clone.recency.Remove(node.Value.recency)
+			node.Value.recency = clone.recency.PushBack(key)
+		}
+	}
+
+	return &clone
+}
@@ -0,0 +1,50 @@
+package lfu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneWithDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithGlobalRecency[string, []int]())
+
+	cache.Put("a", []int{1})
+	cache.Put("b", []int{2})
+	cache.Put("c", []int{3})
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("c")
+	_, _ = cache.Get("b")
+	require.NoError(t, cache.Pin("c"))
+
+	clone := cache.CloneWith(slices.Clone[[]int])
+
+	keys, values := collect(cache.All())
+	cloneKeys, cloneValues := collect(clone.All())
+	require.Equal(t, keys, cloneKeys)
+	require.Equal(t, values, cloneValues)
+	require.Equal(t, cache.FrequenciesOf(keys), clone.FrequenciesOf(keys))
+	require.Equal(t, cache.RecentKeys(3), clone.RecentKeys(3))
+	require.Equal(t, cache.Stats(), clone.Stats())
+	require.True(t, clone.IsPinned("c"))
+	require.NoError(t, clone.Verify())
+
+	value, err := clone.Peek("a")
+	require.NoError(t, err)
+	value[0] = 100
+
+	clone.Put("d", []int{4})
+	require.NoError(t, clone.Unpin("c"))
+
+	original, err := cache.Peek("a")
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, original)
+	require.Equal(t, 3, cache.Size())
+	require.True(t, cache.IsPinned("c"))
+
+	_, err = cache.Peek("d")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
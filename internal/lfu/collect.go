@@ -0,0 +1,20 @@
+package lfu
+
+// Collect reports every cache metric to the given callback in a fixed order.
+// Counter names follow the Prometheus convention and end with _total,
+// so the callback can forward them to any metrics system as is.
+//
+// Reported metrics: lfu_size, lfu_capacity, lfu_utilization,
+// lfu_hits_total, lfu_misses_total, lfu_evictions_total.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Collect(report func(metric string, value float64)) {
+	stats := l.Stats()
+
+	report("lfu_size", float64(l.Size()))
+	report("lfu_capacity", float64(l.Capacity()))
+	report("lfu_utilization", l.utilization())
+	report("lfu_hits_total", float64(stats.Hits))
+	report("lfu_misses_total", float64(stats.Misses))
+	report("lfu_evictions_total", float64(stats.Evictions))
+}
@@ -0,0 +1,45 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(3)
+	_, _ = cache.Get(3)
+	_, _ = cache.Get(1)
+
+	metrics := make(map[string]float64)
+	names := make([]string, 0)
+
+	cache.Collect(func(metric string, value float64) {
+		metrics[metric] = value
+		names = append(names, metric)
+	})
+
+	require.Equal(t, []string{
+		"lfu_size",
+		"lfu_capacity",
+		"lfu_utilization",
+		"lfu_hits_total",
+		"lfu_misses_total",
+		"lfu_evictions_total",
+	}, names)
+	require.Equal(t, map[string]float64{
+		"lfu_size":            2,
+		"lfu_capacity":        2,
+		"lfu_utilization":     1,
+		"lfu_hits_total":      2,
+		"lfu_misses_total":    1,
+		"lfu_evictions_total": 1,
+	}, metrics)
+}
@@ -0,0 +1,60 @@
+package lfu
+
+// comparableCache represents LFU cache of comparable values supporting lookups by value
+type comparableCache[K comparable, V comparable] struct {
+	*cacheImpl[K, V]
+}
+
+// NewComparable initializes the cache of comparable values with the given capacity.
+// Capacity semantics are the same as in New.
+func NewComparable[K comparable, V comparable](capacity ...int) *comparableCache[K, V] {
+	return &comparableCache[K, V]{cacheImpl: New[K, V](capacity...)}
+}
+
+// NewComparableWithOptions initializes the cache of comparable values with the given capacity
+// and applies the options in order, see NewWithOptions.
+func NewComparableWithOptions[K comparable, V comparable](capacity int, options ...Option[K, V]) *comparableCache[K, V] {
+	return &comparableCache[K, V]{cacheImpl: NewWithOptions(capacity, options...)}
+}
+
+// WithDedupeWrites makes Put of a value equal to the stored one a no-op, so idempotent writes
+// do not inflate the frequency: frequency, recency, version and the expiration deadline
+// of the entry stay unchanged. Only writes changing the value count as accesses.
+// Put over a marker stored by PutNegative or over an expired entry is never deduplicated.
+func WithDedupeWrites[K comparable, V comparable]() Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.sameValue = func(a, b V) bool {
+			return a == b
+		}
+	}
+}
+
+// KeyOf returns the first key holding the value in All order, i.e. the most frequently used one.
+// The bool is false if no key holds the value. Frequencies are not changed.
+//
+// O(size)
+func (c *comparableCache[K, V]) KeyOf(value V) (K, bool) {
+	for key, v := range c.All() {
+		if v == value {
+			return key, true
+		}
+	}
+
+	var zero K
+	return zero, false
+}
+
+// DistinctValueCount returns the number of distinct values currently cached. A count much lower
+// than Size means many keys hold equal values, which usually indicates a keying bug.
+// Frequencies are not changed.
+//
+// O(size) time and memory
+func (c *comparableCache[K, V]) DistinctValueCount() int {
+	values := make(map[V]struct{}, c.Size())
+
+	for _, value := range c.All() {
+		values[value] = struct{}{}
+	}
+
+	return len(values)
+}
@@ -0,0 +1,110 @@
+package lfu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyOf(t *testing.T) {
+	t.Parallel()
+
+	cache := NewComparable[string, int](4)
+
+	cache.Put("a", 1)
+	cache.Put("b", 1)
+	cache.Put("c", 1)
+	cache.Put("d", 2)
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("c")
+
+	key, ok := cache.KeyOf(1)
+	require.True(t, ok)
+	require.Equal(t, "b", key)
+
+	key, ok = cache.KeyOf(2)
+	require.True(t, ok)
+	require.Equal(t, "d", key)
+
+	_, ok = cache.KeyOf(3)
+	require.False(t, ok)
+
+	frequency, err := cache.GetKeyFrequency("b")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+}
+
+func TestDedupeWrites(t *testing.T) {
+	t.Parallel()
+
+	cache := NewComparableWithOptions(2, WithDedupeWrites[string, int]())
+
+	cache.Put("a", 1)
+	version := cache.Version()
+
+	for range 5 {
+		cache.Put("a", 1)
+	}
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+	require.Equal(t, version, cache.Version())
+
+	cache.Put("a", 2)
+	cache.Put("a", 3)
+
+	frequency, err = cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+
+	cache.PutNegative("b", 0)
+	cache.Put("b", 0)
+
+	_, err = cache.Get("b")
+	require.NoError(t, err)
+}
+
+func TestDistinctValueCount(t *testing.T) {
+	t.Parallel()
+
+	cache := NewComparable[int, int](10)
+	require.Zero(t, cache.DistinctValueCount())
+
+	for i := range 10 {
+		cache.Put(i, 1)
+	}
+
+	require.Equal(t, 10, cache.Size())
+	require.Equal(t, 1, cache.DistinctValueCount())
+
+	cache.Put(3, 2)
+	cache.Put(4, 3)
+	require.Equal(t, 3, cache.DistinctValueCount())
+
+	require.NoError(t, cache.Remove(4))
+	require.Equal(t, 2, cache.DistinctValueCount())
+}
+
+func TestDedupeWritesAfterExpiration(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewComparableWithOptions(2, WithDedupeWrites[string, int](), WithClock[string, int](clock.Now))
+
+	cache.PutWithTTL("a", 1, time.Minute)
+	clock.Advance(time.Minute)
+
+	cache.Put("a", 1)
+
+	value, err := cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	clock.Advance(time.Hour)
+
+	_, err = cache.Get("a")
+	require.NoError(t, err, "the rewritten entry must not keep the old deadline")
+}
@@ -0,0 +1,14 @@
+//go:build lfudebug
+
+package lfu
+
+// debugEnabled reports whether the cache is built with the lfudebug tag.
+const debugEnabled = true
+
+// debugVerify panics if the cache violates its invariants, see Verify.
+// It is called after every mutating method in builds with the lfudebug tag.
+func (l *cacheImpl[K, V]) debugVerify() {
+	if err := l.Verify(); err != nil {
+		panic("lfu: " + err.Error())
+	}
+}
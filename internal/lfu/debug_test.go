@@ -0,0 +1,62 @@
+//go:build lfudebug
+
+package lfu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugVerifyComplexSequence(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(8,
+		WithMaxContainers[int, int](4),
+		WithSecondaryPolicy[int, int](SecondaryFIFO),
+	)
+
+	require.NotPanics(t, func() {
+		for i := range 200 {
+			cache.Put(i%13, i)
+
+			for range i % 5 {
+				_, _ = cache.Get((i * 7) % 13)
+			}
+
+			switch i % 10 {
+			case 3:
+				_ = cache.Remove(i % 13)
+			case 5:
+				_ = cache.Rekey(i%13, 100+i)
+			case 7:
+				_ = cache.SwapFrequencies(i%13, (i+1)%13)
+			case 9:
+				cache.SetCapacity(4 + i%6)
+			}
+		}
+
+		cache.BumpMany([]int{1, 2, 3})
+		cache.Rerank(func(_ int, value int, _ int) int { return value % 4 })
+		_ = cache.CoalesceFrequencies(1, 2)
+
+		var buf bytes.Buffer
+		require.NoError(t, cache.EncodeGob(&buf))
+		require.NoError(t, cache.DecodeGob(&buf))
+	})
+}
+
+func TestDebugVerifyPanicsOnCorruption(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	delete(cache.index, "b")
+
+	require.Panics(t, func() {
+		_, _ = cache.Get("a")
+	})
+}
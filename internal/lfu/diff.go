@@ -0,0 +1,37 @@
+package lfu
+
+// Diff compares the contents of two caches without changing frequencies.
+// It returns the keys present only in here, the keys present only in there
+// and the keys present in both but holding different values.
+// Keys are listed in the All order of the cache they are taken from.
+//
+// O(here.Size() + there.Size())
+func Diff[K comparable, V comparable](here, there Cache[K, V]) (onlyHere, onlyThere, differingValues []K) {
+	thereValues := make(map[K]V, there.Size())
+	for key, value := range there.All() {
+		thereValues[key] = value
+	}
+
+	hereKeys := make(map[K]struct{}, here.Size())
+
+	for key, value := range here.All() {
+		hereKeys[key] = struct{}{}
+
+		thereValue, ok := thereValues[key]
+
+		switch {
+		case !ok:
+			onlyHere = append(onlyHere, key)
+		case thereValue != value:
+			differingValues = append(differingValues, key)
+		}
+	}
+
+	for key := range there.All() {
+		if _, ok := hereKeys[key]; !ok {
+			onlyThere = append(onlyThere, key)
+		}
+	}
+
+	return onlyHere, onlyThere, differingValues
+}
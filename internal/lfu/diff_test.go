@@ -0,0 +1,37 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	here := New[string, int](5)
+	there := New[string, int](5)
+
+	here.Put("shared", 1)
+	here.Put("changed", 2)
+	here.Put("local", 3)
+	here.Put("local2", 4)
+
+	there.Put("shared", 1)
+	there.Put("changed", 20)
+	there.Put("remote", 5)
+
+	onlyHere, onlyThere, differing := Diff[string, int](here, there)
+	require.Equal(t, []string{"local2", "local"}, onlyHere)
+	require.Equal(t, []string{"remote"}, onlyThere)
+	require.Equal(t, []string{"changed"}, differing)
+
+	frequency, err := here.GetKeyFrequency("shared")
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+
+	onlyHere, onlyThere, differing = Diff[string, int](here, here)
+	require.Empty(t, onlyHere)
+	require.Empty(t, onlyThere)
+	require.Empty(t, differing)
+}
@@ -0,0 +1,23 @@
+package lfu
+
+// Entry is a copy of a single cache entry.
+type Entry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	Frequency int
+}
+
+// RankedEntry is a copy of a single cache entry with its dense frequency rank:
+// entries with the highest frequency have rank 1, entries with the next lower one rank 2, and so on.
+type RankedEntry[K comparable, V any] struct {
+	Rank      int
+	Key       K
+	Value     V
+	Frequency int
+}
+
+// FrequencyCount is the number of entries sharing a frequency.
+type FrequencyCount struct {
+	Frequency int
+	Count     int
+}
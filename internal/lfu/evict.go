@@ -0,0 +1,66 @@
+package lfu
+
+import (
+	"context"
+	"log/slog"
+
+	"lfucache/internal/linkedlist"
+)
+
+// EvictReason tells why an entry left the cache.
+type EvictReason int
+
+const (
+	// ReasonCapacity means the entry was evicted to make room for another one.
+	ReasonCapacity EvictReason = iota + 1
+	// ReasonManual means the entry was removed explicitly, e.g. by Remove.
+	ReasonManual
+	// ReasonExpired means the entry was removed after its TTL passed.
+	ReasonExpired
+)
+
+// String returns the reason name.
+func (r EvictReason) String() string {
+	switch r {
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonManual:
+		return "manual"
+	case ReasonExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// WithOnEvict sets a callback called after an entry leaves the cache, with the reason.
+// Overwriting the value of a key is not an eviction and is not reported.
+// The callback must not modify the cache.
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V, reason EvictReason)) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.onEvict = onEvict
+	}
+}
+
+// WithSlogger makes the cache log every entry leaving it, for the same reasons WithOnEvict reports,
+// at debug level with the attributes key, frequency and reason.
+func WithSlogger[K comparable, V any](logger *slog.Logger) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.logger = logger
+	}
+}
+
+// notifyEvict reports the removed entry to the OnEvict callback and the logger if they are set.
+func (l *cacheImpl[K, V]) notifyEvict(node *linkedlist.Node[cacheData[K, V]], reason EvictReason) {
+	if l.logger != nil {
+		l.logger.LogAttrs(context.Background(), slog.LevelDebug, "lfu: entry evicted",
+			slog.Any("key", node.Value.key),
+			slog.Int("frequency", node.Value.container.Value.freq),
+			slog.String("reason", reason.String()),
+		)
+	}
+
+	if l.onEvict != nil {
+		l.onEvict(node.Value.key, node.Value.value, reason)
+	}
+}
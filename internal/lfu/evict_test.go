@@ -0,0 +1,113 @@
+package lfu
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type evicted struct {
+	key    int
+	value  int
+	reason EvictReason
+}
+
+func TestOnEvictReasons(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	log := make([]evicted, 0)
+
+	cache := NewWithOptions(2,
+		WithClock[int, int](clock.Now),
+		WithOnEvict(func(key int, value int, reason EvictReason) {
+			log = append(log, evicted{key: key, value: value, reason: reason})
+		}),
+	)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(2, 21)
+	cache.Put(3, 30)
+	require.NoError(t, cache.Remove(2))
+
+	cache.PutWithTTL(4, 40, time.Second)
+	clock.Advance(time.Second)
+	_, _ = cache.Get(4)
+
+	require.Equal(t, []evicted{
+		{key: 1, value: 10, reason: ReasonCapacity},
+		{key: 2, value: 21, reason: ReasonManual},
+		{key: 4, value: 40, reason: ReasonExpired},
+	}, log)
+
+	require.Equal(t, "capacity", ReasonCapacity.String())
+	require.Equal(t, "manual", ReasonManual.String())
+	require.Equal(t, "expired", ReasonExpired.String())
+	require.Equal(t, "unknown", EvictReason(0).String())
+}
+
+func TestRemoveMany(t *testing.T) {
+	t.Parallel()
+
+	removed := make([]int, 0)
+
+	cache := NewWithOptions(5, WithOnEvict(func(key int, _ int, reason EvictReason) {
+		require.Equal(t, ReasonManual, reason)
+		removed = append(removed, key)
+	}))
+
+	for i := 1; i <= 5; i++ {
+		cache.Put(i, i*10)
+	}
+
+	require.Equal(t, 3, cache.RemoveMany([]int{2, 7, 4, 2, 5}))
+	require.Equal(t, []int{2, 4, 5}, removed)
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []int{3, 1}, keys)
+	require.Equal(t, []int{30, 10}, values)
+	require.Equal(t, 0, cache.RemoveMany(nil))
+}
+
+type capturingHandler struct {
+	records []slog.Record
+}
+
+func (h *capturingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *capturingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *capturingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *capturingHandler) WithGroup(string) slog.Handler { return h }
+
+func TestSloggerLogsEvictions(t *testing.T) {
+	t.Parallel()
+
+	handler := &capturingHandler{}
+	cache := NewWithOptions(1, WithSlogger[string, int](slog.New(handler)))
+
+	cache.Put("a", 1)
+	_, _ = cache.Get("a")
+	cache.Put("b", 2)
+
+	require.Len(t, handler.records, 1)
+
+	record := handler.records[0]
+	require.Equal(t, slog.LevelDebug, record.Level)
+
+	attrs := make(map[string]any)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value.Any()
+		return true
+	})
+
+	require.Equal(t, map[string]any{"key": "a", "frequency": int64(2), "reason": "capacity"}, attrs)
+}
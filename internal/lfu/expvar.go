@@ -0,0 +1,65 @@
+package lfu
+
+import (
+	"errors"
+	"expvar"
+	"sync"
+)
+
+var ErrAlreadyPublished = errors.New("expvar name already published")
+
+// expvarStats is the JSON representation of the published variable.
+type expvarStats struct {
+	Size        int     `json:"size"`
+	Capacity    int     `json:"capacity"`
+	Hits        uint64  `json:"hits"`
+	Misses      uint64  `json:"misses"`
+	Evictions   uint64  `json:"evictions"`
+	Utilization float64 `json:"utilization"`
+}
+
+// expvarMu serializes the check and the registration in publishExpvar,
+// so concurrent calls with the same name cannot both pass the check.
+var expvarMu sync.Mutex
+
+// PublishExpvar registers an expvar.Var with the given name exposing the cache
+// stats as JSON. The values are read from the cache each time the variable is
+// rendered, so they are always up to date.
+//
+// The variable is rendered from the goroutine serving the expvar handler, so the bare cache
+// may only be published if it is not modified concurrently, e.g. for a snapshot that is no longer used.
+// Use the PublishExpvar of the cache returned by NewSynchronized for a cache in use.
+//
+// Returns ErrAlreadyPublished if a variable with the same name already exists,
+// since expvar itself panics on double registration.
+func (l *cacheImpl[K, V]) PublishExpvar(name string) error {
+	return publishExpvar(name, l.expvarStats)
+}
+
+func (l *cacheImpl[K, V]) expvarStats() expvarStats {
+	stats := l.Stats()
+
+	return expvarStats{
+		Size:        l.Size(),
+		Capacity:    l.Capacity(),
+		Hits:        stats.Hits,
+		Misses:      stats.Misses,
+		Evictions:   stats.Evictions,
+		Utilization: l.utilization(),
+	}
+}
+
+func publishExpvar(name string, read func() expvarStats) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return ErrAlreadyPublished
+	}
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return read()
+	}))
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package lfu
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+	require.NoError(t, cache.PublishExpvar("TestPublishExpvar"))
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(42)
+
+	var published expvarStats
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("TestPublishExpvar").String()), &published))
+	require.Equal(t, expvarStats{
+		Size:        2,
+		Capacity:    4,
+		Hits:        1,
+		Misses:      1,
+		Evictions:   0,
+		Utilization: 0.5,
+	}, published)
+
+	cache.Put(3, 30)
+	cache.Put(4, 40)
+	cache.Put(5, 50)
+
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("TestPublishExpvar").String()), &published))
+	require.Equal(t, 4, published.Size)
+	require.Equal(t, uint64(1), published.Evictions)
+	require.InDelta(t, 1.0, published.Utilization, 1e-9)
+}
+
+func TestPublishExpvarTwice(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](1)
+	require.NoError(t, cache.PublishExpvar("TestPublishExpvarTwice"))
+	require.ErrorIs(t, New[int, int](1).PublishExpvar("TestPublishExpvarTwice"), ErrAlreadyPublished)
+}
+
+func TestPublishExpvarConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var (
+		wg        sync.WaitGroup
+		published atomic.Int64
+	)
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if New[int, int](1).PublishExpvar("TestPublishExpvarConcurrently") == nil {
+				published.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int64(1), published.Load())
+}
+
+func TestSynchronizedPublishExpvar(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[int, int](100)
+	require.NoError(t, cache.PublishExpvar("TestSynchronizedPublishExpvar"))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := range 1000 {
+			cache.Put(i%200, i)
+			_, _ = cache.Get(i % 150)
+		}
+	}()
+
+	var published expvarStats
+	for range 100 {
+		require.NoError(t, json.Unmarshal([]byte(expvar.Get("TestSynchronizedPublishExpvar").String()), &published))
+		require.LessOrEqual(t, published.Size, published.Capacity)
+	}
+
+	<-done
+
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("TestSynchronizedPublishExpvar").String()), &published))
+	require.Equal(t, 100, published.Size)
+	require.Equal(t, uint64(1000), published.Hits+published.Misses)
+}
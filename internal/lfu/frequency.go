@@ -0,0 +1,322 @@
+package lfu
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"lfucache/internal/linkedlist"
+)
+
+// ErrFrequencyNotFound is returned when no entry of the cache has the requested frequency.
+var ErrFrequencyNotFound = errors.New("frequency not found")
+
+// WithMaxFrequency caps the frequency of entries at max. Accessing an entry at the cap
+// makes it the most recently used one without incrementing its frequency, so long-lived
+// hot entries cannot accumulate a frequency that new entries never reach.
+// The number of accesses is still tracked, see HitCount. A non-positive max means no cap.
+func WithMaxFrequency[K comparable, V any](maxFrequency int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.maxFrequency = maxFrequency
+	}
+}
+
+// WithPutFrequencyDelta sets how much Put of a present key increments its frequency, 1 by default.
+// With 0 such a Put only replaces the value: frequency, recency and the hit count stay unchanged.
+// Negative deltas are treated as 0. Get always increments the frequency by 1.
+func WithPutFrequencyDelta[K comparable, V any](delta int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.putFrequencyDelta = max(0, delta)
+	}
+}
+
+// WithInsertFrequency makes Put of a new key place it at frequency n instead of 1, capped
+// by WithMaxFrequency. Fresh entries then get a grace period: they are not evicted before
+// the entries of lower frequency, so a scan of one-off keys evicts earlier one-off keys
+// instead of recently inserted entries that had no time to be accessed yet.
+// Values below 1 are treated as 1. Only Put and the methods built on it are affected,
+// restored entries (DecodeGob, NewPrewarmed, ...) keep their frequencies.
+//
+// With n above 1 inserting a new key is no longer O(1): its container is looked up from
+// the highest frequency down, which takes O(number of containers above n).
+func WithInsertFrequency[K comparable, V any](n int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.insertFrequency = max(1, n)
+	}
+}
+
+// HitCount returns the number of accesses of the key since it was inserted,
+// counting the insertion itself and every access that increments the frequency
+// (Get, Put of an existing key, ...). Unlike the frequency it is never capped or
+// changed by frequency manipulation such as Rerank.
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) HitCount(key K) (int, error) {
+	node, ok := l.index[key]
+	if !ok {
+		return 0, ErrKeyNotFound
+	}
+
+	return node.Value.hits, nil
+}
+
+// SameFrequency reports whether both keys have the same frequency, i.e. share a container.
+// Frequencies are not changed.
+//
+// Returns ErrKeyNotFound if either key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) SameFrequency(a, b K) (bool, error) {
+	first, ok := l.index[a]
+	if !ok {
+		return false, ErrKeyNotFound
+	}
+
+	second, ok := l.index[b]
+	if !ok {
+		return false, ErrKeyNotFound
+	}
+
+	return first.Value.container == second.Value.container, nil
+}
+
+// Rerank calls f for every entry and moves the entry to the frequency f returns,
+// floored at 1 and capped by WithMaxFrequency. Entries are visited in eviction order: lower frequencies first,
+// the least recently used first within a frequency. Entries ending up with the same
+// frequency keep that relative order, so an entry that was colder stays older.
+// Values, pins and expiration deadlines are not changed. f must not modify the cache.
+//
+// O(size * log(size))
+func (l *cacheImpl[K, V]) Rerank(f func(key K, value V, freq int) int) {
+	defer l.debugVerify()
+
+	type reranked struct {
+		node *linkedlist.Node[cacheData[K, V]]
+		freq int
+	}
+
+	entries := make([]reranked, 0, l.Size())
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			freq := f(node.Value.key, node.Value.value, container.Value.freq)
+			entries = append(entries, reranked{node: node, freq: l.boundFrequency(freq)})
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b reranked) int {
+		return cmp.Compare(a.freq, b.freq)
+	})
+
+	for _, entry := range entries {
+		entry.node.Value.container.Value.entries.Remove(entry.node)
+	}
+
+	l.sequence = linkedlist.List[sameFreqContainer[K, V]]{}
+	l.sequence.PushBack(sameFreqContainer[K, V]{freq: 1})
+
+	for _, entry := range entries {
+		container := l.containerFor(entry.freq)
+		container.Value.entries.PushBackNode(entry.node)
+		entry.node.Value.container = container
+	}
+
+	l.boundContainers()
+}
+
+// boundFrequency floors freq at 1 and caps it by WithMaxFrequency.
+func (l *cacheImpl[K, V]) boundFrequency(freq int) int {
+	if l.maxFrequency > 0 {
+		freq = min(freq, l.maxFrequency)
+	}
+
+	return max(1, freq)
+}
+
+// SetFrequency moves the entry to exactly the given frequency, floored at 1 and capped
+// by WithMaxFrequency, creating the container if needed, as the most recently used
+// entry of that frequency.
+// It is not an access: stats, hit count and the global recency are not changed.
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(number of containers above freq)
+func (l *cacheImpl[K, V]) SetFrequency(key K, freq int) error {
+	defer l.debugVerify()
+
+	node, ok := l.index[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	current := node.Value.container
+	target := l.containerFor(l.boundFrequency(freq))
+
+	current.Value.entries.Remove(node)
+	target.Value.entries.PushBackNode(node)
+	node.Value.container = target
+
+	l.dropIfEmpty(current)
+	l.boundContainers()
+
+	return nil
+}
+
+// CoalesceFrequencies moves all entries with frequency from to frequency into, placing them
+// as the most recently used ones of that frequency in their existing relative order.
+// It is a building block for custom aging: repeatedly coalescing the hottest frequencies
+// into lower ones decays the cache without touching individual entries.
+//
+// Both frequencies must be present in the cache, otherwise ErrFrequencyNotFound is returned.
+// Frequency 1 is always present, even without entries.
+//
+// O(number of containers + number of moved entries)
+func (l *cacheImpl[K, V]) CoalesceFrequencies(into, from int) error {
+	defer l.debugVerify()
+
+	target := l.findContainer(into)
+	source := l.findContainer(from)
+
+	if target == nil || source == nil {
+		return ErrFrequencyNotFound
+	}
+
+	if target == source {
+		return nil
+	}
+
+	l.coalesce(target, source)
+
+	return nil
+}
+
+// CompactContainers removes every empty container except the frequency 1 one and returns
+// the number of removed containers. Methods of the cache never leave such containers behind,
+// see Verify, so it is only needed to restore the invariant after manipulating containers
+// directly, e.g. in a custom frequency manipulation built inside the package.
+// ContainerCount is not affected, since it only counts containers holding entries.
+//
+// O(number of containers)
+func (l *cacheImpl[K, V]) CompactContainers() int {
+	defer l.debugVerify()
+
+	removed := 0
+
+	for container := l.sequence.Head().Next(); container != nil; {
+		next := container.Next()
+
+		if container.Value.entries.Len() == 0 {
+			l.dropIfEmpty(container)
+			removed++
+		}
+
+		container = next
+	}
+
+	return removed
+}
+
+// WithMaxContainers bounds the number of distinct frequencies, which bounds the cost
+// of walking the containers in eviction and iteration under adversarial workloads.
+// Whenever an access would create the (n+1)th frequency, the two lowest frequencies present
+// are coalesced: entries of the second lowest one get the lowest frequency and become
+// its most recently used entries. Eviction still prefers lower frequencies.
+// A non-positive n means no bound.
+//
+// An access that triggers coalescing costs O(number of entries with the second lowest frequency).
+func WithMaxContainers[K comparable, V any](n int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.maxContainers = n
+	}
+}
+
+// coalesce moves all entries of source to the most recently used end of target
+// and drops source if it is not the root.
+func (l *cacheImpl[K, V]) coalesce(target, source *linkedlist.Node[sameFreqContainer[K, V]]) {
+	for source.Value.entries.Len() > 0 {
+		node := source.Value.entries.Head()
+		source.Value.entries.Remove(node)
+		target.Value.entries.PushBackNode(node)
+		node.Value.container = target
+	}
+
+	l.dropIfEmpty(source)
+}
+
+// boundContainers coalesces the lowest frequencies until the number of non-empty containers
+// fits WithMaxContainers. Only the root container may be empty.
+func (l *cacheImpl[K, V]) boundContainers() {
+	if l.maxContainers <= 0 {
+		return
+	}
+
+	for {
+		lowest := l.sequence.Head()
+		count := l.sequence.Len()
+
+		if lowest.Value.entries.Len() == 0 {
+			lowest = lowest.Next()
+			count--
+		}
+
+		if count <= l.maxContainers {
+			return
+		}
+
+		l.coalesce(lowest, lowest.Next())
+	}
+}
+
+// findContainer returns the container with the given frequency or nil if there is none.
+func (l *cacheImpl[K, V]) findContainer(freq int) *linkedlist.Node[sameFreqContainer[K, V]] {
+	for container := l.sequence.Head(); container != nil && container.Value.freq <= freq; container = container.Next() {
+		if container.Value.freq == freq {
+			return container
+		}
+	}
+
+	return nil
+}
+
+// touchBy is touch incrementing the frequency by delta, respecting WithMaxFrequency.
+// A non-positive delta leaves the entry untouched.
+//
+// O(1) for delta 1, O(min(delta, number of containers)) otherwise
+func (l *cacheImpl[K, V]) touchBy(node *linkedlist.Node[cacheData[K, V]], delta int) {
+	if delta <= 0 {
+		return
+	}
+
+	if delta == 1 {
+		l.touch(node)
+		return
+	}
+
+	current := node.Value.container
+	node.Value.hits++
+	l.accesses++
+
+	freq := current.Value.freq + delta
+	if l.maxFrequency > 0 {
+		freq = min(freq, max(l.maxFrequency, current.Value.freq))
+	}
+
+	target := current
+	for next := target.Next(); next != nil && next.Value.freq <= freq; next = target.Next() {
+		target = next
+	}
+
+	if target.Value.freq != freq {
+		target = l.sequence.InsertAfter(sameFreqContainer[K, V]{freq: freq}, target)
+	}
+
+	current.Value.entries.Remove(node)
+	target.Value.entries.PushBackNode(node)
+	node.Value.container = target
+	l.markRecent(node)
+
+	l.dropIfEmpty(current)
+	l.boundContainers()
+}
@@ -0,0 +1,479 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRerankHalving(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](6)
+
+	for i := 1; i <= 6; i++ {
+		cache.Put(i, i*10)
+	}
+
+	// frequencies: 1 -> 1, 2 -> 2, 3 -> 3, 4 -> 4, 5 -> 5, 6 -> 6
+	for i := 1; i <= 6; i++ {
+		for range i - 1 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	cache.Rerank(func(_ int, _ int, freq int) int {
+		return freq / 2
+	})
+
+	require.Equal(t, map[int]int{1: 1, 2: 1, 3: 1, 4: 2, 5: 2, 6: 3}, cache.FrequenciesOf([]int{1, 2, 3, 4, 5, 6}))
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []int{6, 5, 4, 3, 2, 1}, keys)
+	require.Equal(t, []int{60, 50, 40, 30, 20, 10}, values)
+	require.Equal(t, 3, cache.ContainerCount())
+
+	cache.Put(7, 70)
+	_, err := cache.GetKeyFrequency(1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestRerankByValue(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	cache.Put("a", 3)
+	cache.Put("b", 1)
+	cache.Put("c", 2)
+	_, _ = cache.Get("b")
+
+	cache.Rerank(func(_ string, value int, _ int) int {
+		return value
+	})
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"a", "c", "b"}, keys)
+
+	_, _ = cache.Get("b")
+	require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 2}, cache.FrequenciesOf([]string{"a", "b", "c"}))
+
+	keys, _ = collect(cache.All())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+}
+
+func TestRerankWithFrequencyCap(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithMaxFrequency[string, int](3))
+
+	cache.Put("a", 10)
+	cache.Put("b", 2)
+
+	cache.Rerank(func(_ string, value int, _ int) int {
+		return value
+	})
+
+	require.Equal(t, map[string]int{"a": 3, "b": 2}, cache.FrequenciesOf([]string{"a", "b"}))
+	require.NoError(t, cache.Verify())
+}
+
+func TestHitCountWithFrequencyCap(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithMaxFrequency[int, int](3))
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+
+	for i := 2; i <= 10; i++ {
+		_, _ = cache.Get(1)
+
+		hits, err := cache.HitCount(1)
+		require.NoError(t, err)
+		require.Equal(t, i, hits)
+
+		frequency, err := cache.GetKeyFrequency(1)
+		require.NoError(t, err)
+		require.Equal(t, min(i, 3), frequency)
+	}
+
+	_, _ = cache.Get(2)
+	_, _ = cache.Get(2)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{2, 1}, keys)
+
+	_, _ = cache.Get(1)
+	keys, _ = collect(cache.All())
+	require.Equal(t, []int{1, 2}, keys)
+	require.Equal(t, 1, cache.ContainerCount())
+
+	_, err := cache.HitCount(3)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestHitCountWithoutCapMatchesFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(1, 11)
+	_, _ = cache.Get(1)
+
+	hits, err := cache.HitCount(1)
+	require.NoError(t, err)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, frequency, hits)
+}
+
+func TestCoalesceFrequencies(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](6)
+
+	for i, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		cache.Put(key, i)
+	}
+
+	for _, key := range []string{"a", "b", "c", "d", "c", "d"} {
+		_, _ = cache.Get(key)
+	}
+
+	require.NoError(t, cache.CoalesceFrequencies(2, 3))
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"d", "c", "b", "a", "f", "e"}, keys)
+	require.Equal(t, map[string]int{"a": 2, "b": 2, "c": 2, "d": 2, "e": 1, "f": 1}, cache.FrequenciesOf(keys))
+	require.NoError(t, cache.Verify())
+
+	require.NoError(t, cache.CoalesceFrequencies(1, 2))
+
+	keys, _ = collect(cache.All())
+	require.Equal(t, []string{"d", "c", "b", "a", "f", "e"}, keys)
+	require.Equal(t, 1, cache.ContainerCount())
+	require.NoError(t, cache.Verify())
+
+	require.NoError(t, cache.CoalesceFrequencies(1, 1))
+	require.ErrorIs(t, cache.CoalesceFrequencies(1, 2), ErrFrequencyNotFound)
+	require.ErrorIs(t, cache.CoalesceFrequencies(5, 1), ErrFrequencyNotFound)
+}
+
+func TestMaxContainers(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(20, WithMaxContainers[int, int](3))
+
+	for i := range 20 {
+		cache.Put(i, i)
+
+		for range i {
+			_, _ = cache.Get(i)
+			require.LessOrEqual(t, cache.ContainerCount(), 3)
+		}
+	}
+
+	require.NoError(t, cache.Verify())
+
+	frequencies := make([]int, 0)
+	for freq := range cache.Tiers() {
+		frequencies = append(frequencies, freq)
+	}
+
+	require.Len(t, frequencies, 3)
+
+	for i := 100; i < 105; i++ {
+		cache.Put(i, i)
+		require.LessOrEqual(t, cache.ContainerCount(), 3)
+	}
+
+	_, err := cache.Get(19)
+	require.NoError(t, err)
+
+	coldest, _, ok := cache.Coldest()
+	require.True(t, ok)
+
+	frequency, err := cache.GetKeyFrequency(coldest)
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+}
+
+func TestMaxContainersAfterPut(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithMaxContainers[string, int](2))
+
+	cache.Put("a", 1)
+	_, _ = cache.Get("a")
+	cache.Put("b", 2)
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("b")
+
+	cache.Put("c", 3)
+	require.Equal(t, 2, cache.ContainerCount())
+	require.Equal(t, map[string]int{"a": 1, "b": 3, "c": 1}, cache.FrequenciesOf([]string{"a", "b", "c"}))
+	require.NoError(t, cache.Verify())
+}
+
+func TestMaxContainersOne(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithMaxContainers[string, int](1))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("a")
+
+	require.Equal(t, map[string]int{"a": 1, "b": 1}, cache.FrequenciesOf([]string{"a", "b"}))
+
+	cache.Put("c", 3)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"c", "a"}, keys)
+}
+
+func TestMaxContainersKeepsLowestPresentFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithMaxContainers[string, int](2))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	for _, key := range []string{"a", "b", "c", "a", "a"} {
+		_, _ = cache.Get(key)
+	}
+
+	require.Equal(t, map[string]int{"a": 4, "b": 2, "c": 2}, cache.FrequenciesOf([]string{"a", "b", "c"}))
+
+	// Creates frequency 3 for "b", so frequencies 2 and 3 are coalesced, not 1 and 2.
+	_, _ = cache.Get("b")
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	require.Equal(t, map[string]int{"a": 4, "b": 2, "c": 2}, cache.FrequenciesOf(keys))
+	require.NoError(t, cache.Verify())
+}
+
+func TestSetFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("b")
+
+	require.NoError(t, cache.SetFrequency("a", 5))
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	require.Equal(t, map[string]int{"a": 5, "b": 2, "c": 1}, cache.FrequenciesOf(keys))
+	require.Equal(t, 3, cache.ContainerCount())
+
+	require.NoError(t, cache.SetFrequency("b", 1))
+
+	keys, _ = collect(cache.All())
+	require.Equal(t, []string{"a", "b", "c"}, keys)
+	require.Equal(t, 2, cache.ContainerCount())
+
+	require.NoError(t, cache.SetFrequency("a", 3))
+	require.NoError(t, cache.SetFrequency("c", -4))
+
+	keys, _ = collect(cache.All())
+	require.Equal(t, []string{"a", "c", "b"}, keys)
+	require.Equal(t, map[string]int{"a": 3, "b": 1, "c": 1}, cache.FrequenciesOf(keys))
+	require.NoError(t, cache.Verify())
+
+	require.ErrorIs(t, cache.SetFrequency("x", 2), ErrKeyNotFound)
+	require.Equal(t, Stats{Hits: 1}, cache.Stats())
+}
+
+func TestSetFrequencyWithFrequencyCap(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithMaxFrequency[string, int](3))
+
+	cache.Put("a", 1)
+	require.NoError(t, cache.SetFrequency("a", 10))
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+	require.NoError(t, cache.Verify())
+}
+
+func TestPutFrequencyDelta(t *testing.T) {
+	t.Parallel()
+
+	frequencyAfterPuts := func(delta int) int {
+		cache := NewWithOptions(2, WithPutFrequencyDelta[string, int](delta))
+
+		cache.Put("a", 1)
+		cache.Put("a", 2)
+
+		value, err := cache.Peek("a")
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+		require.NoError(t, cache.Verify())
+
+		frequency, err := cache.GetKeyFrequency("a")
+		require.NoError(t, err)
+
+		return frequency
+	}
+
+	require.Equal(t, 1, frequencyAfterPuts(0))
+	require.Equal(t, 1, frequencyAfterPuts(-1))
+	require.Equal(t, 2, frequencyAfterPuts(1))
+	require.Equal(t, 3, frequencyAfterPuts(2))
+
+	cache := NewWithOptions(3,
+		WithPutFrequencyDelta[string, int](2),
+		WithMaxFrequency[string, int](4),
+	)
+
+	cache.Put("a", 1)
+	cache.Put("b", 1)
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("b")
+	cache.Put("a", 2)
+	cache.Put("a", 3)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"a", "b"}, keys)
+	require.Equal(t, map[string]int{"a": 4, "b": 3}, cache.FrequenciesOf(keys))
+	require.NoError(t, cache.Verify())
+}
+
+func TestInsertFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithInsertFrequency[string, int](3))
+
+	cache.Put("old1", 1)
+	cache.Put("old2", 2)
+
+	frequency, err := cache.GetKeyFrequency("old1")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+
+	// Pre-existing entries that cooled down, e.g. after a re-ranking.
+	require.NoError(t, cache.SetFrequency("old1", 1))
+	require.NoError(t, cache.SetFrequency("old2", 1))
+
+	cache.Put("fresh", 3)
+	cache.Put("scan1", 4)
+	cache.Put("scan2", 5)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"scan2", "scan1", "fresh"}, keys)
+
+	cache.Put("fresh", 30)
+
+	frequency, err = cache.GetKeyFrequency("fresh")
+	require.NoError(t, err)
+	require.Equal(t, 4, frequency)
+	require.NoError(t, cache.Verify())
+}
+
+func TestInsertFrequencyBounds(t *testing.T) {
+	t.Parallel()
+
+	capped := NewWithOptions(2, WithInsertFrequency[int, int](5), WithMaxFrequency[int, int](2))
+	capped.Put(1, 1)
+
+	frequency, err := capped.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+
+	floored := NewWithOptions(2, WithInsertFrequency[int, int](-3))
+	floored.Put(1, 1)
+
+	frequency, err = floored.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+	require.False(t, floored.HasEmptyRootContainer())
+}
+
+func TestSameFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+
+	for range 3 {
+		_, _ = cache.Get("a")
+		_, _ = cache.Get("b")
+	}
+
+	_, _ = cache.Get("c")
+
+	keys := []string{"a", "b", "c", "d"}
+	frequencies := cache.FrequenciesOf(keys)
+
+	for _, a := range keys {
+		for _, b := range keys {
+			same, err := cache.SameFrequency(a, b)
+			require.NoError(t, err)
+			require.Equal(t, frequencies[a] == frequencies[b], same, "%s and %s", a, b)
+		}
+	}
+
+	same, _ := cache.SameFrequency("a", "b")
+	require.True(t, same)
+
+	same, _ = cache.SameFrequency("c", "d")
+	require.False(t, same)
+
+	_, err := cache.SameFrequency("a", "missing")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = cache.SameFrequency("missing", "a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestCompactContainers(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+	require.Zero(t, cache.CompactContainers())
+
+	for i := range 4 {
+		cache.Put(i, i)
+
+		for range i * 2 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	require.NoError(t, cache.Verify())
+	require.Zero(t, cache.CompactContainers())
+
+	require.NoError(t, cache.SetFrequency(0, 4))
+
+	// Empty containers left behind by a manipulation that bypasses dropIfEmpty.
+	cache.containerFor(2)
+	cache.containerFor(6)
+	cache.containerFor(100)
+
+	require.ErrorIs(t, cache.Verify(), ErrEmptyNonRootContainer)
+	require.Equal(t, 4, cache.ContainerCount())
+	require.Equal(t, 8, cache.sequence.Len())
+
+	require.Equal(t, 3, cache.CompactContainers())
+	require.NoError(t, cache.Verify())
+	require.Equal(t, 4, cache.ContainerCount())
+	require.Equal(t, 4+1, cache.sequence.Len(), "the empty frequency 1 container is kept")
+	require.Equal(t, map[int]int{0: 4, 1: 3, 2: 5, 3: 7}, cache.FrequenciesOf([]int{0, 1, 2, 3}))
+}
@@ -0,0 +1,84 @@
+package lfu
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+// gobEntry is the encoded form of a single entry.
+type gobEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	Frequency int
+	Negative  bool
+	Reserved  bool
+}
+
+// gobSnapshot is the encoded form of the cache.
+// Entries are stored in eviction order: from the coldest to the hottest one.
+type gobSnapshot[K comparable, V any] struct {
+	Capacity int
+	Entries  []gobEntry[K, V]
+}
+
+// EncodeGob writes keys, values and frequencies of all entries to w using encoding/gob.
+// Both K and V must be encodable by encoding/gob.
+// Markers stored by PutNegative and reservations stored by Reserve are encoded as such. Expiration deadlines, pins and stats are not encoded,
+// so a decoded marker never expires.
+//
+// O(size)
+func (l *cacheImpl[K, V]) EncodeGob(w io.Writer) error {
+	snapshot := gobSnapshot[K, V]{
+		Capacity: l.Capacity(),
+		Entries:  make([]gobEntry[K, V], 0, l.Size()),
+	}
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			snapshot.Entries = append(snapshot.Entries, gobEntry[K, V]{
+				Key:       node.Value.key,
+				Value:     node.Value.value,
+				Frequency: container.Value.freq,
+				Negative:  node.Value.negative,
+				Reserved:  node.Value.reserved,
+			})
+		}
+	}
+
+	return gob.NewEncoder(w).Encode(snapshot)
+}
+
+// DecodeGob replaces the cache contents with the entries written by EncodeGob,
+// restoring their frequencies and recency order. The capacity of the cache is kept:
+// if the encoded cache holds more entries than fit, the coldest ones are dropped.
+//
+// On error the cache is left unchanged.
+//
+// O(size of the encoded cache)
+func (l *cacheImpl[K, V]) DecodeGob(r io.Reader) error {
+	defer l.debugVerify()
+
+	var snapshot gobSnapshot[K, V]
+	if err := gob.NewDecoder(r).Decode(&snapshot); err != nil {
+		return err
+	}
+
+	l.reset()
+
+	entries := snapshot.Entries
+	if len(entries) > l.Capacity() {
+		entries = entries[len(entries)-l.Capacity():]
+	}
+
+	for _, entry := range entries {
+		if _, ok := l.index[entry.Key]; ok {
+			continue
+		}
+
+		node := l.insertWithFrequency(entry.Key, entry.Value, entry.Frequency)
+		node.Value.negative = entry.Negative
+		node.Value.reserved = entry.Reserved
+	}
+
+	return nil
+}
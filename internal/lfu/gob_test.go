@@ -0,0 +1,73 @@
+package lfu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, []byte](4)
+
+	cache.Put("a", []byte("alpha"))
+	cache.Put("b", []byte("beta"))
+	cache.Put("c", []byte("gamma"))
+	cache.Put("d", []byte("delta"))
+	_, _ = cache.Get("c")
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("b")
+
+	var buf bytes.Buffer
+	require.NoError(t, cache.EncodeGob(&buf))
+
+	restored := New[string, []byte](4)
+	restored.Put("z", []byte("stale"))
+	require.NoError(t, restored.DecodeGob(&buf))
+
+	expectedKeys, expectedValues := collect(cache.All())
+	keys, values := collect(restored.All())
+	require.Equal(t, expectedKeys, keys)
+	require.Equal(t, expectedValues, values)
+	require.Equal(t, cache.FrequenciesOf(keys), restored.FrequenciesOf(keys))
+
+	_, err := restored.Get("z")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestGobDecodeIntoSmallerCache(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("a")
+
+	var buf bytes.Buffer
+	require.NoError(t, cache.EncodeGob(&buf))
+
+	restored := New[string, int](2)
+	require.NoError(t, restored.DecodeGob(&buf))
+
+	keys, _ := collect(restored.All())
+	require.Equal(t, []string{"a", "c"}, keys)
+
+	frequency, err := restored.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+}
+
+func TestGobDecodeError(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+
+	require.Error(t, cache.DecodeGob(bytes.NewBufferString("garbage")))
+	require.Equal(t, 1, cache.Size())
+}
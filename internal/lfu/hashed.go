@@ -0,0 +1,129 @@
+package lfu
+
+import (
+	"iter"
+	"slices"
+)
+
+// hashedSlot holds a key of the hashedCache, its address is the key of the underlying cache.
+type hashedSlot[K any] struct {
+	key  K
+	hash uint64
+}
+
+// hashedCache represents LFU cache for keys that are not comparable or are expensive to hash by the map.
+// Keys are found through buckets of slots keyed by their precomputed hash, while frequencies,
+// recency and eviction are left to an ordinary cache keyed by the slot address,
+// so the LFU logic is not duplicated for a second key type.
+type hashedCache[K any, V any] struct {
+	cache   *cacheImpl[*hashedSlot[K], V]
+	buckets map[uint64][]*hashedSlot[K]
+	hasher  func(key K) uint64
+	eq      func(a, b K) bool
+}
+
+// NewHashed initializes the cache with the given capacity for keys of any type.
+// Keys are located by hasher and compared by eq, which must agree:
+// equal keys must have equal hashes. Colliding keys are kept as separate entries,
+// each lookup compares the key with every key sharing its hash.
+// Capacity semantics are the same as in New.
+func NewHashed[K any, V any](hasher func(key K) uint64, eq func(a, b K) bool, capacity int) *hashedCache[K, V] {
+	c := &hashedCache[K, V]{
+		buckets: make(map[uint64][]*hashedSlot[K]),
+		hasher:  hasher,
+		eq:      eq,
+	}
+	c.cache = NewWithOptions(capacity, WithOnEvict(func(slot *hashedSlot[K], _ V, _ EvictReason) {
+		c.forget(slot)
+	}))
+
+	return c
+}
+
+// Get returns the value of the key, see Cache.Get.
+//
+// O(number of keys with the same hash), not amortized
+func (c *hashedCache[K, V]) Get(key K) (V, error) {
+	slot, ok := c.lookup(key)
+	if !ok {
+		c.cache.stats.Misses++
+
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	return c.cache.Get(slot)
+}
+
+// Put updates or inserts the value of the key, see Cache.Put.
+//
+// O(number of keys with the same hash), not amortized
+func (c *hashedCache[K, V]) Put(key K, value V) {
+	if slot, ok := c.lookup(key); ok {
+		c.cache.Put(slot, value)
+		return
+	}
+
+	slot := &hashedSlot[K]{key: key, hash: c.hasher(key)}
+	if c.cache.TryPut(slot, value) {
+		c.buckets[slot.hash] = append(c.buckets[slot.hash], slot)
+	}
+}
+
+// GetKeyFrequency returns the frequency of the key, see Cache.GetKeyFrequency.
+//
+// O(number of keys with the same hash), not amortized
+func (c *hashedCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	slot, ok := c.lookup(key)
+	if !ok {
+		return 0, ErrKeyNotFound
+	}
+
+	return c.cache.GetKeyFrequency(slot)
+}
+
+// All returns the iterator over keys and values, see Cache.All.
+func (c *hashedCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for slot, value := range c.cache.All() {
+			if !yield(slot.key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the cache size.
+func (c *hashedCache[K, V]) Size() int {
+	return c.cache.Size()
+}
+
+// Capacity returns the cache capacity.
+func (c *hashedCache[K, V]) Capacity() int {
+	return c.cache.Capacity()
+}
+
+// lookup returns the slot holding the key.
+func (c *hashedCache[K, V]) lookup(key K) (*hashedSlot[K], bool) {
+	for _, slot := range c.buckets[c.hasher(key)] {
+		if c.eq(slot.key, key) {
+			return slot, true
+		}
+	}
+
+	return nil, false
+}
+
+// forget removes the slot of an entry that left the underlying cache.
+func (c *hashedCache[K, V]) forget(slot *hashedSlot[K]) {
+	bucket := slices.DeleteFunc(c.buckets[slot.hash], func(other *hashedSlot[K]) bool {
+		return other == slot
+	})
+
+	if len(bucket) == 0 {
+		delete(c.buckets, slot.hash)
+		return
+	}
+
+	c.buckets[slot.hash] = bucket
+}
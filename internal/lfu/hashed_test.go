@@ -0,0 +1,53 @@
+package lfu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pathKey struct {
+	segments []string
+}
+
+func TestHashedSliceKeys(t *testing.T) {
+	t.Parallel()
+
+	// Collides on purpose: the hash depends only on the number of segments.
+	hasher := func(key pathKey) uint64 { return uint64(len(key.segments)) }
+	eq := func(a, b pathKey) bool { return slices.Equal(a.segments, b.segments) }
+
+	cache := NewHashed[pathKey, int](hasher, eq, 2)
+
+	cache.Put(pathKey{[]string{"a", "b"}}, 1)
+	cache.Put(pathKey{[]string{"a", "c"}}, 2)
+	cache.Put(pathKey{[]string{"a", "b"}}, 10)
+
+	value, err := cache.Get(pathKey{[]string{"a", "b"}})
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	frequency, err := cache.GetKeyFrequency(pathKey{[]string{"a", "b"}})
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+	require.Equal(t, 2, cache.Size())
+
+	cache.Put(pathKey{[]string{"x"}}, 3)
+
+	_, err = cache.Get(pathKey{[]string{"a", "c"}})
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = cache.GetKeyFrequency(pathKey{[]string{"a", "c"}})
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	var keys [][]string
+	for key := range cache.All() {
+		keys = append(keys, key.segments)
+	}
+
+	require.Equal(t, [][]string{{"a", "b"}, {"x"}}, keys)
+	require.Equal(t, 2, cache.Capacity())
+	require.Len(t, cache.buckets, 2)
+	require.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1}, cache.cache.Stats())
+}
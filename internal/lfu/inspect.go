@@ -0,0 +1,364 @@
+package lfu
+
+import (
+	"iter"
+	"math"
+	"slices"
+
+	"lfucache/internal/linkedlist"
+)
+
+// FrequenciesOf returns the frequency of every given key present in the cache.
+// Absent and reserved keys (see Reserve) are omitted from the result. Frequencies are not changed.
+//
+// O(len(keys))
+func (l *cacheImpl[K, V]) FrequenciesOf(keys []K) map[K]int {
+	frequencies := make(map[K]int, len(keys))
+
+	for _, key := range keys {
+		if node, ok := l.index[key]; ok && !node.Value.reserved {
+			frequencies[key] = node.Value.container.Value.freq
+		}
+	}
+
+	return frequencies
+}
+
+// Tiers returns the iterator over non-empty frequency containers in ascending
+// order of frequency. Each container is yielded as its frequency and a fresh
+// slice of its keys, the most recently used key first.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) Tiers() iter.Seq2[int, []K] {
+	return func(yield func(int, []K) bool) {
+		for container := l.sequence.Head(); container != nil; container = container.Next() {
+			if container.Value.entries.Len() == 0 {
+				continue
+			}
+
+			keys := make([]K, 0, container.Value.entries.Len())
+			for node := container.Value.entries.Tail(); node != nil; node = node.Prev() {
+				keys = append(keys, node.Value.key)
+			}
+
+			if !yield(container.Value.freq, keys) {
+				return
+			}
+		}
+	}
+}
+
+// FrequencySpectrum returns the number of entries of every present frequency
+// in ascending order of frequency. Frequencies without entries are omitted.
+//
+// O(number of containers)
+func (l *cacheImpl[K, V]) FrequencySpectrum() []FrequencyCount {
+	spectrum := make([]FrequencyCount, 0, l.sequence.Len())
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		if container.Value.entries.Len() == 0 {
+			continue
+		}
+
+		spectrum = append(spectrum, FrequencyCount{
+			Frequency: container.Value.freq,
+			Count:     container.Value.entries.Len(),
+		})
+	}
+
+	return spectrum
+}
+
+// CountBelowFrequency returns the number of entries with frequency strictly lower than freq.
+// Frequencies are not changed.
+//
+// O(number of containers below freq)
+func (l *cacheImpl[K, V]) CountBelowFrequency(freq int) int {
+	count := 0
+
+	for container := l.sequence.Head(); container != nil && container.Value.freq < freq; container = container.Next() {
+		count += container.Value.entries.Len()
+	}
+
+	return count
+}
+
+// Coldest returns the entry that would be evicted next to make room for a new key:
+// the least recently used one among the least frequently used ones, taking pins,
+// the eviction veto and the key tie-break into account.
+// The bool is false if there is no such entry. Frequencies are not changed.
+//
+// O(1), not amortized, under the same conditions as eviction
+func (l *cacheImpl[K, V]) Coldest() (K, V, bool) {
+	node := l.victim()
+	if node == nil {
+		var (
+			zeroKey   K
+			zeroValue V
+		)
+
+		return zeroKey, zeroValue, false
+	}
+
+	return node.Value.key, node.Value.value, true
+}
+
+// Hottest returns the first entry yielded by All, i.e. by default the most recently used
+// entry among the most frequently used ones. The bool is false if the cache is empty.
+// Frequencies are not changed.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Hottest() (K, V, bool) {
+	if node := l.firstIn(l.sequence.Tail()); node != nil {
+		return node.Value.key, node.Value.value, true
+	}
+
+	var (
+		zeroKey   K
+		zeroValue V
+	)
+
+	return zeroKey, zeroValue, false
+}
+
+// Nth returns the entry at position n, starting at 0, in the order of All,
+// i.e. in descending order of frequency: Nth(0) is Hottest.
+// The bool is false if n is out of [0, Size). Frequencies are not changed.
+//
+// O(number of containers + n)
+func (l *cacheImpl[K, V]) Nth(n int) (Entry[K, V], bool) {
+	if n < 0 || n >= l.Size() {
+		return Entry[K, V]{}, false
+	}
+
+	container := l.sequence.Tail()
+	for ; n >= container.Value.entries.Len(); container = container.Prev() {
+		n -= container.Value.entries.Len()
+	}
+
+	node := l.firstIn(container)
+	for ; n > 0; n-- {
+		node = l.successor(node)
+	}
+
+	return Entry[K, V]{
+		Key:       node.Value.key,
+		Value:     node.Value.value,
+		Frequency: container.Value.freq,
+	}, true
+}
+
+// SortedKeys returns the keys sorted by less, which compares whole entries, so keys can be ranked
+// by value, frequency or key. The sort is stable: entries less considers equal keep the order of All.
+// Frequencies are not changed.
+//
+// O(size * log(size))
+func (l *cacheImpl[K, V]) SortedKeys(less func(a, b Entry[K, V]) bool) []K {
+	entries := l.EntriesInto(nil)
+
+	slices.SortStableFunc(entries, func(a, b Entry[K, V]) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	keys := make([]K, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Key
+	}
+
+	return keys
+}
+
+// HasEmptyRootContainer reports whether no entry has frequency 1. The frequency 1 container
+// is kept even when empty, so a new entry is inserted without allocating a container.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) HasEmptyRootContainer() bool {
+	return l.sequence.Head().Value.entries.Len() == 0
+}
+
+// ContainerCount returns the number of distinct frequencies among the entries.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) ContainerCount() int {
+	count := 0
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		if container.Value.entries.Len() > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// LargestContainerSize returns the number of entries sharing the most common frequency.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) LargestContainerSize() int {
+	largest := 0
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		largest = max(largest, container.Value.entries.Len())
+	}
+
+	return largest
+}
+
+// Peek returns the value of the key like Get but does not change the frequency,
+// the recency or the stats. Expired entries are reported as absent but not removed,
+// reserved keys (see Reserve) are reported as absent too. Like Get, Peek of a marker
+// stored by PutNegative returns ErrNegativeCached.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Peek(key K) (V, error) {
+	node, ok := l.index[key]
+	if !ok || node.Value.reserved || l.expired(node) {
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	if node.Value.negative {
+		return node.Value.value, ErrNegativeCached
+	}
+
+	return node.Value.value, nil
+}
+
+// PreviewEvictions returns up to n entries in the order they would be evicted
+// if the cache kept evicting without new entries competing for eviction:
+// lower frequencies first, the least recently used first within a frequency
+// (or the smallest key with WithKeyTieBreak). Pinned and vetoed entries are skipped.
+// Frequencies are not changed.
+//
+// O(n) without pins, the veto and the tie-break, O(size) in the worst case otherwise
+func (l *cacheImpl[K, V]) PreviewEvictions(n int) []Entry[K, V] {
+	preview := make([]Entry[K, V], 0, max(0, min(n, l.Size())))
+
+	for node := range l.evictionOrder() {
+		if len(preview) >= n {
+			break
+		}
+
+		preview = append(preview, Entry[K, V]{
+			Key:       node.Value.key,
+			Value:     node.Value.value,
+			Frequency: node.Value.container.Value.freq,
+		})
+	}
+
+	return preview
+}
+
+// BottomPercentile returns the coldest ceil(p * Size()) entries in eviction order,
+// see PreviewEvictions. Fewer entries are returned if some are pinned or vetoed.
+// Frequencies are not changed.
+//
+// Panics if p is not within (0, 1].
+//
+// O(p * size) without pins, the veto and the tie-break, O(size) in the worst case otherwise
+func (l *cacheImpl[K, V]) BottomPercentile(p float64) []Entry[K, V] {
+	if !(p > 0 && p <= 1) {
+		panic("lfu: percentile must be within (0, 1]")
+	}
+
+	return l.PreviewEvictions(int(math.Ceil(p * float64(l.Size()))))
+}
+
+// EvictionOrderStream sends the keys of evictable entries to the returned channel in the order
+// of PreviewEvictions and closes it after the last one. The channel is unbuffered: keys are
+// produced by a goroutine walking the live structure only as fast as they are received.
+//
+// The channel must be drained, otherwise the goroutine is never released, and the cache
+// must not be used in any way, including Get, until the channel is closed.
+// See synchronizedCache.EvictionOrderStream for a variant without this restriction.
+//
+// O(size) in total, O(1) memory
+func (l *cacheImpl[K, V]) EvictionOrderStream() <-chan K {
+	keys := make(chan K)
+
+	go func() {
+		defer close(keys)
+
+		for node := range l.evictionOrder() {
+			keys <- node.Value.key
+		}
+	}()
+
+	return keys
+}
+
+// evictionOrder returns the iterator over evictable entries in eviction order, see victim.
+func (l *cacheImpl[K, V]) evictionOrder() iter.Seq[*linkedlist.Node[cacheData[K, V]]] {
+	return func(yield func(*linkedlist.Node[cacheData[K, V]]) bool) {
+		if l.scoreWeights != nil {
+			for _, node := range l.scoredOrder() {
+				if !yield(node) {
+					return
+				}
+			}
+
+			return
+		}
+
+		var sorted []*linkedlist.Node[cacheData[K, V]]
+
+		for container := l.sequence.Head(); container != nil; container = container.Next() {
+			sorted = sorted[:0]
+
+			for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+				if !l.evictable(node) {
+					continue
+				}
+
+				if l.breaksTies() {
+					sorted = append(sorted, node)
+					continue
+				}
+
+				if !yield(node) {
+					return
+				}
+			}
+
+			slices.SortStableFunc(sorted, l.compareTies)
+
+			for _, node := range sorted {
+				if !yield(node) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Rankings returns all entries in the same order as All, each with its dense rank by frequency:
+// entries with equal frequencies share a rank and ranks have no gaps. Frequencies are not changed.
+//
+// O(size)
+func (l *cacheImpl[K, V]) Rankings() []RankedEntry[K, V] {
+	rankings := make([]RankedEntry[K, V], 0, l.Size())
+	rank := 0
+
+	for node := l.first(); node != nil; node = l.successor(node) {
+		freq := node.Value.container.Value.freq
+		if rank == 0 || rankings[len(rankings)-1].Frequency != freq {
+			rank++
+		}
+
+		rankings = append(rankings, RankedEntry[K, V]{
+			Rank:      rank,
+			Key:       node.Value.key,
+			Value:     node.Value.value,
+			Frequency: freq,
+		})
+	}
+
+	return rankings
+}
@@ -0,0 +1,565 @@
+package lfu
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrequenciesOf(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(2)
+	_, _ = cache.Get(3)
+	_, _ = cache.Get(3)
+
+	frequencies := cache.FrequenciesOf([]int{1, 2, 3, 4})
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 3}, frequencies)
+
+	for key, frequency := range frequencies {
+		actual, err := cache.GetKeyFrequency(key)
+		require.NoError(t, err)
+		require.Equal(t, actual, frequency)
+	}
+
+	_, ok := frequencies[4]
+	require.False(t, ok)
+
+	require.Equal(t, map[int]int{1: 1, 2: 2, 3: 3}, cache.FrequenciesOf([]int{1, 2, 3}))
+}
+
+func TestTiers(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](5)
+
+	for i := 1; i <= 5; i++ {
+		cache.Put(i, i*10)
+	}
+
+	_, _ = cache.Get(2)
+	_, _ = cache.Get(4)
+	_, _ = cache.Get(4)
+	_, _ = cache.Get(5)
+
+	frequencies := make([]int, 0)
+	tiers := make([][]int, 0)
+
+	for frequency, keys := range cache.Tiers() {
+		frequencies = append(frequencies, frequency)
+		tiers = append(tiers, keys)
+	}
+
+	require.Equal(t, []int{1, 2, 3}, frequencies)
+	require.Equal(t, [][]int{{3, 1}, {5, 2}, {4}}, tiers)
+
+	slices.Reverse(tiers)
+	allKeys, _ := collect(cache.All())
+	require.Equal(t, allKeys, slices.Concat(tiers...))
+}
+
+func TestTiersSkipsEmptyRoot(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](1)
+	cache.Put(1, 10)
+	_, _ = cache.Get(1)
+
+	for frequency, keys := range cache.Tiers() {
+		require.Equal(t, 2, frequency)
+		require.Equal(t, []int{1}, keys)
+	}
+}
+
+func TestCountBelowFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](10)
+
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+
+		for range i % 4 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	for threshold := 0; threshold <= 6; threshold++ {
+		expected := 0
+
+		for i := 0; i < 10; i++ {
+			frequency, err := cache.GetKeyFrequency(i)
+			require.NoError(t, err)
+
+			if frequency < threshold {
+				expected++
+			}
+		}
+
+		require.Equal(t, expected, cache.CountBelowFrequency(threshold), "threshold %d", threshold)
+	}
+
+	require.Equal(t, 3, cache.CountBelowFrequency(2))
+}
+
+func TestColdestMatchesEvicted(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+
+	_, _, ok := cache.Coldest()
+	require.False(t, ok)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(3)
+
+	for i := 4; i < 10; i++ {
+		key, value, ok := cache.Coldest()
+		require.True(t, ok)
+		require.Equal(t, key*10, value)
+
+		frequencies := cache.FrequenciesOf([]int{key})
+		cache.Put(i, i*10)
+
+		_, err := cache.GetKeyFrequency(key)
+		require.ErrorIs(t, err, ErrKeyNotFound, "expected %d (frequency %d) to be evicted", key, frequencies[key])
+	}
+}
+
+func TestHottestMatchesAll(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+
+	_, _, ok := cache.Hottest()
+	require.False(t, ok)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(2)
+	_, _ = cache.Get(3)
+
+	for range 3 {
+		key, value, ok := cache.Hottest()
+		require.True(t, ok)
+
+		for firstKey, firstValue := range cache.All() {
+			require.Equal(t, firstKey, key)
+			require.Equal(t, firstValue, value)
+
+			break
+		}
+
+		_, _ = cache.Get(1)
+	}
+
+	key, _, _ := cache.Hottest()
+	require.Equal(t, 1, key)
+}
+
+func TestContainerFragmentation(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+
+	cache := New[int, int](n)
+	require.Equal(t, 0, cache.ContainerCount())
+	require.Equal(t, 0, cache.LargestContainerSize())
+
+	for i := 0; i < n; i++ {
+		cache.Put(i, i)
+	}
+
+	require.Equal(t, 1, cache.ContainerCount())
+	require.Equal(t, n, cache.LargestContainerSize())
+
+	_, _ = cache.Get(0)
+	require.Equal(t, 2, cache.ContainerCount())
+	require.Equal(t, n-1, cache.LargestContainerSize())
+
+	for i := 1; i < n; i++ {
+		_, _ = cache.Get(i)
+	}
+
+	require.Equal(t, 1, cache.ContainerCount())
+	require.Equal(t, n, cache.LargestContainerSize())
+
+	for i := 0; i < n; i++ {
+		for range i {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	require.Equal(t, n, cache.ContainerCount())
+	require.Equal(t, 1, cache.LargestContainerSize())
+}
+
+func TestPeek(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+
+	value, err := cache.Peek(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	_, err = cache.Peek(3)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+	require.Equal(t, Stats{}, cache.Stats())
+
+	cache.Put(3, 30)
+
+	_, err = cache.Peek(1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestPreviewEvictionsMatchesEvictions(t *testing.T) {
+	t.Parallel()
+
+	evictedKeys := make([]int, 0)
+	cache := NewWithOptions(6, WithOnEvict(func(key int, _ int, _ EvictReason) {
+		evictedKeys = append(evictedKeys, key)
+	}))
+
+	for i := 1; i <= 6; i++ {
+		cache.Put(i, i*10)
+
+		for range (i * 5) % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	preview := cache.PreviewEvictions(4)
+	require.Len(t, preview, 4)
+
+	for _, entry := range preview {
+		frequency, err := cache.GetKeyFrequency(entry.Key)
+		require.NoError(t, err)
+		require.Equal(t, frequency, entry.Frequency)
+		require.Equal(t, entry.Key*10, entry.Value)
+	}
+
+	for i := 100; i < 104; i++ {
+		cache.Put(i, i)
+
+		for range 10 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	expected := make([]int, 0, len(preview))
+	for _, entry := range preview {
+		expected = append(expected, entry.Key)
+	}
+
+	require.Equal(t, expected, evictedKeys)
+}
+
+func TestPreviewEvictionsBounds(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithKeyTieBreak[int, int](cmp.Compare[int]))
+
+	cache.Put(3, 30)
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	_, _ = cache.Get(1)
+	require.NoError(t, cache.Pin(2))
+
+	require.Empty(t, cache.PreviewEvictions(0))
+	require.Empty(t, cache.PreviewEvictions(-1))
+	require.Equal(t, []Entry[int, int]{
+		{Key: 3, Value: 30, Frequency: 1},
+		{Key: 1, Value: 10, Frequency: 2},
+	}, cache.PreviewEvictions(10))
+}
+
+func TestBottomPercentile(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](100)
+
+	for i := range 100 {
+		cache.Put(i, i)
+
+		for range (i * 7) % 5 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	bottom := cache.BottomPercentile(0.1)
+	require.Equal(t, cache.PreviewEvictions(10), bottom)
+
+	for _, entry := range bottom {
+		require.Equal(t, 1, entry.Frequency)
+	}
+
+	require.Len(t, cache.BottomPercentile(0.001), 1)
+	require.Len(t, cache.BottomPercentile(1), 100)
+	require.Panics(t, func() { cache.BottomPercentile(0) })
+	require.Panics(t, func() { cache.BottomPercentile(1.5) })
+	require.Panics(t, func() { cache.BottomPercentile(math.NaN()) })
+}
+
+func TestRankings(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](5)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+	cache.Put("e", 5)
+
+	for range 4 {
+		_, _ = cache.Get("a")
+	}
+
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("c")
+
+	require.Equal(t, []RankedEntry[string, int]{
+		{Rank: 1, Key: "a", Value: 1, Frequency: 5},
+		{Rank: 2, Key: "c", Value: 3, Frequency: 2},
+		{Rank: 2, Key: "b", Value: 2, Frequency: 2},
+		{Rank: 3, Key: "e", Value: 5, Frequency: 1},
+		{Rank: 3, Key: "d", Value: 4, Frequency: 1},
+	}, cache.Rankings())
+
+	require.Empty(t, New[string, int](2).Rankings())
+}
+
+func TestEvictionOrderStream(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](20)
+	synchronized := NewSynchronized[int, int](20)
+
+	for i := range 20 {
+		cache.Put(i, i)
+		synchronized.Put(i, i)
+
+		for range (i * 3) % 4 {
+			_, _ = cache.Get(i)
+			_, _ = synchronized.Get(i)
+		}
+	}
+
+	require.NoError(t, cache.Pin(7))
+
+	expected := make([]int, 0)
+	for _, entry := range cache.PreviewEvictions(cache.Size()) {
+		expected = append(expected, entry.Key)
+	}
+
+	streamed := make([]int, 0)
+	for key := range cache.EvictionOrderStream() {
+		streamed = append(streamed, key)
+	}
+
+	require.Equal(t, expected, streamed)
+	require.NotContains(t, streamed, 7)
+
+	streamed = streamed[:0]
+	for key := range synchronized.EvictionOrderStream() {
+		synchronized.Put(100+key, key)
+		streamed = append(streamed, key)
+	}
+
+	require.Len(t, streamed, 20)
+	require.ElementsMatch(t, append(expected, 7), streamed)
+}
+
+func TestHasEmptyRootContainer(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+	require.True(t, cache.HasEmptyRootContainer())
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	require.False(t, cache.HasEmptyRootContainer())
+
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("b")
+	require.True(t, cache.HasEmptyRootContainer())
+	require.NoError(t, cache.Verify())
+
+	cache.Put("c", 3)
+	require.False(t, cache.HasEmptyRootContainer())
+}
+
+func TestFrequencySpectrum(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](10)
+	require.Empty(t, cache.FrequencySpectrum())
+
+	for i := range 10 {
+		cache.Put(i, i)
+
+		for range i % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	for i := range 3 {
+		_, _ = cache.Get(i * 3)
+	}
+
+	spectrum := cache.FrequencySpectrum()
+	require.Equal(t, []FrequencyCount{
+		{Frequency: 1, Count: 1},
+		{Frequency: 2, Count: 6},
+		{Frequency: 3, Count: 3},
+	}, spectrum)
+
+	total := 0
+	for i, bucket := range spectrum {
+		total += bucket.Count
+
+		if i > 0 {
+			require.Greater(t, bucket.Frequency, spectrum[i-1].Frequency)
+		}
+	}
+
+	require.Equal(t, cache.Size(), total)
+}
+
+func TestNth(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](10)
+
+	_, ok := cache.Nth(0)
+	require.False(t, ok)
+
+	for i := range 10 {
+		cache.Put(i, i*10)
+
+		for range (i * 7) % 4 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	for position, entry := range cache.AllIndexed() {
+		nth, ok := cache.Nth(position)
+		require.True(t, ok)
+		require.Equal(t, entry, nth)
+	}
+
+	hottest, ok := cache.Nth(0)
+	require.True(t, ok)
+
+	key, value, _ := cache.Hottest()
+	require.Equal(t, key, hottest.Key)
+	require.Equal(t, value, hottest.Value)
+
+	coldest, ok := cache.Nth(cache.Size() - 1)
+	require.True(t, ok)
+
+	key, value, _ = cache.Coldest()
+	require.Equal(t, key, coldest.Key)
+	require.Equal(t, value, coldest.Value)
+
+	_, ok = cache.Nth(cache.Size())
+	require.False(t, ok)
+
+	_, ok = cache.Nth(-1)
+	require.False(t, ok)
+}
+
+func TestNthLRUFirst(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(4, WithIterationOrder[string, int](LRUFirst))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("c")
+
+	keys := make([]string, 0)
+	for i := range cache.Size() {
+		entry, ok := cache.Nth(i)
+		require.True(t, ok)
+
+		keys = append(keys, entry.Key)
+	}
+
+	allKeys, _ := collect(cache.All())
+	require.Equal(t, allKeys, keys)
+	require.Equal(t, []string{"c", "a", "b"}, keys)
+
+	_, _ = cache.Get("a")
+
+	first, ok := cache.Nth(0)
+	require.True(t, ok)
+	require.Equal(t, "c", first.Key)
+
+	hottest, _, ok := cache.Hottest()
+	require.True(t, ok)
+	require.Equal(t, first.Key, hottest)
+
+	keys, _ = collect(cache.All())
+	changed := make([]string, 0)
+	for _, entry := range cache.ChangedSince(0) {
+		changed = append(changed, entry.Key)
+	}
+
+	require.Equal(t, keys, changed)
+}
+
+func TestSortedKeys(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+	require.Empty(t, cache.SortedKeys(func(a, b Entry[string, int]) bool { return a.Value < b.Value }))
+
+	cache.Put("a", 30)
+	cache.Put("b", 10)
+	cache.Put("c", 40)
+	cache.Put("d", 20)
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("c")
+
+	defaultOrder, _ := collect(cache.All())
+	require.Equal(t, []string{"a", "c", "d", "b"}, defaultOrder)
+
+	byValue := cache.SortedKeys(func(a, b Entry[string, int]) bool {
+		return a.Value < b.Value
+	})
+	require.Equal(t, []string{"b", "d", "a", "c"}, byValue)
+
+	byKey := cache.SortedKeys(func(a, b Entry[string, int]) bool {
+		return a.Key < b.Key
+	})
+	require.Equal(t, []string{"a", "b", "c", "d"}, byKey)
+
+	// Equal frequencies keep the order of All.
+	byFrequency := cache.SortedKeys(func(a, b Entry[string, int]) bool {
+		return a.Frequency < b.Frequency
+	})
+	require.Equal(t, []string{"d", "b", "c", "a"}, byFrequency)
+}
@@ -0,0 +1,210 @@
+package lfu
+
+import (
+	"iter"
+	"slices"
+
+	"lfucache/internal/linkedlist"
+)
+
+// IterationOrder defines the order in which All yields entries of the same frequency.
+type IterationOrder int
+
+const (
+	// MRUFirst yields the most recently used entry first. This is the default.
+	MRUFirst IterationOrder = iota
+	// LRUFirst yields the least recently used entry first, the reverse of MRUFirst.
+	// Entries not used since they were inserted are thus yielded in insertion order,
+	// but an entry used without changing its frequency, e.g. at WithMaxFrequency,
+	// moves behind the others.
+	LRUFirst
+)
+
+// WithIterationOrder sets the order in which All and the iterators built on it
+// yield entries of the same frequency. Entries are always yielded in descending
+// order of frequency, and eviction order is not affected.
+func WithIterationOrder[K comparable, V any](order IterationOrder) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.iterationOrder = order
+	}
+}
+
+// Filter returns the iterator over entries satisfying pred in the same order as All.
+// Entries are not copied and frequencies are not changed.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) Filter(pred func(key K, value V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range l.All() {
+			if pred(key, value) && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// AllLimit returns the iterator over at most n first entries in the same order as All.
+// For n <= 0 nothing is yielded.
+//
+// O(min(n, size))
+func (l *cacheImpl[K, V]) AllLimit(n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		yielded := 0
+
+		for key, value := range l.All() {
+			if !yield(key, value) {
+				return
+			}
+
+			yielded++
+			if yielded == n {
+				return
+			}
+		}
+	}
+}
+
+// EntriesInFrequencyRange returns the iterator over entries with frequency within [lo, hi]
+// in ascending order of frequency, the most recently used entry first within a frequency.
+// Like All, it walks the live structure and does not change frequencies.
+//
+// O(number of containers below lo + number of yielded entries)
+func (l *cacheImpl[K, V]) EntriesInFrequencyRange(lo, hi int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		container := l.sequence.Head()
+		for container != nil && container.Value.freq < lo {
+			container = container.Next()
+		}
+
+		for ; container != nil && container.Value.freq <= hi; container = container.Next() {
+			for node := container.Value.entries.Tail(); node != nil; node = node.Prev() {
+				if !yield(node.Value.key, node.Value.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AllIndexed returns the iterator over entries in the same order as All, each with
+// its position in that order, starting at 0. Like All, it walks the live structure.
+//
+// O(capacity)
+func (l *cacheImpl[K, V]) AllIndexed() iter.Seq2[int, Entry[K, V]] {
+	return func(yield func(int, Entry[K, V]) bool) {
+		position := 0
+
+		for node := l.first(); node != nil; node = l.successor(node) {
+			entry := Entry[K, V]{
+				Key:       node.Value.key,
+				Value:     node.Value.value,
+				Frequency: node.Value.container.Value.freq,
+			}
+
+			if !yield(position, entry) {
+				return
+			}
+
+			position++
+		}
+	}
+}
+
+// EntriesInto appends all entries to buf[:0] in the same order as All and returns the result,
+// so a caller polling the cache can reuse one scratch slice instead of allocating on every call.
+// buf is grown once if its capacity is below Size. Pass nil to get a freshly allocated slice.
+//
+// O(size), no allocations when cap(buf) >= size
+func (l *cacheImpl[K, V]) EntriesInto(buf []Entry[K, V]) []Entry[K, V] {
+	buf = slices.Grow(buf[:0], l.Size())
+
+	for _, entry := range l.AllIndexed() {
+		buf = append(buf, entry)
+	}
+
+	return buf
+}
+
+// AllSnapshot copies all entries at the moment of the call and returns the iterator
+// over the copy in the same order as All. The cache may be freely modified
+// during the iteration, including by Get.
+//
+// O(size) memory and time at the moment of the call
+func (l *cacheImpl[K, V]) AllSnapshot() iter.Seq2[K, V] {
+	keys := make([]K, 0, l.Size())
+	values := make([]V, 0, l.Size())
+
+	for key, value := range l.All() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return func(yield func(K, V) bool) {
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap returns a fresh map holding the same key-value pairs as All,
+// except for markers stored by PutNegative and reservations stored by Reserve, which hold no value.
+// Frequencies and order are discarded, and the map shares nothing with the cache.
+//
+// O(size)
+func (l *cacheImpl[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, l.Size())
+
+	for node := l.first(); node != nil; node = l.successor(node) {
+		if !node.Value.placeholder() {
+			result[node.Value.key] = node.Value.value
+		}
+	}
+
+	return result
+}
+
+// first returns the entry yielded first by All or nil if the cache is empty.
+func (l *cacheImpl[K, V]) first() *linkedlist.Node[cacheData[K, V]] {
+	for container := l.sequence.Tail(); container != nil; container = container.Prev() {
+		if node := l.firstIn(container); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// successor returns the entry yielded by All right after the node or nil if it is the last one.
+func (l *cacheImpl[K, V]) successor(node *linkedlist.Node[cacheData[K, V]]) *linkedlist.Node[cacheData[K, V]] {
+	next := node.Prev()
+	if l.iterationOrder == LRUFirst {
+		next = node.Next()
+	}
+
+	if next != nil {
+		return next
+	}
+
+	for container := node.Value.container.Prev(); container != nil; container = container.Prev() {
+		if next := l.firstIn(container); next != nil {
+			return next
+		}
+	}
+
+	return nil
+}
+
+// firstIn returns the entry of the container yielded first by All or nil if the container is empty.
+func (l *cacheImpl[K, V]) firstIn(container *linkedlist.Node[sameFreqContainer[K, V]]) *linkedlist.Node[cacheData[K, V]] {
+	if l.iterationOrder == LRUFirst {
+		return container.Value.entries.Head()
+	}
+
+	return container.Value.entries.Tail()
+}
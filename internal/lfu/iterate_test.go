@@ -0,0 +1,306 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](6)
+
+	for i := 1; i <= 6; i++ {
+		cache.Put(i, i*10)
+
+		for range i % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	even := func(key int, _ int) bool {
+		return key%2 == 0
+	}
+
+	allKeys, _ := collect(cache.All())
+	expected := make([]int, 0)
+
+	for _, key := range allKeys {
+		if key%2 == 0 {
+			expected = append(expected, key)
+		}
+	}
+
+	keys, values := collect(cache.Filter(even))
+	require.Equal(t, expected, keys)
+
+	for i, key := range keys {
+		require.Equal(t, key*10, values[i])
+	}
+
+	frequencies := cache.FrequenciesOf(allKeys)
+	_, _ = collect(cache.Filter(even))
+	require.Equal(t, frequencies, cache.FrequenciesOf(allKeys))
+
+	for key := range cache.Filter(even) {
+		require.Equal(t, expected[0], key)
+		break
+	}
+}
+
+func TestAllLimit(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](5)
+
+	for i := 1; i <= 5; i++ {
+		cache.Put(i, i*10)
+
+		for range i % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	allKeys, allValues := collect(cache.All())
+
+	for n := -1; n <= 7; n++ {
+		keys, values := collect(cache.AllLimit(n))
+		expected := max(0, min(n, cache.Size()))
+
+		require.Len(t, keys, expected)
+		require.Equal(t, allKeys[:expected], keys)
+		require.Equal(t, allValues[:expected], values)
+	}
+}
+
+func TestEntriesInFrequencyRange(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](8)
+
+	for i := 1; i <= 8; i++ {
+		cache.Put(i, i*10)
+
+		for range i % 4 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	for lo := 0; lo <= 5; lo++ {
+		for hi := lo - 1; hi <= 5; hi++ {
+			expected := make(map[int]int)
+
+			for key, value := range cache.All() {
+				frequency, err := cache.GetKeyFrequency(key)
+				require.NoError(t, err)
+
+				if frequency >= lo && frequency <= hi {
+					expected[key] = value
+				}
+			}
+
+			actual := make(map[int]int)
+			previous := 0
+
+			for key, value := range cache.EntriesInFrequencyRange(lo, hi) {
+				frequency, err := cache.GetKeyFrequency(key)
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, frequency, previous)
+
+				previous = frequency
+				actual[key] = value
+			}
+
+			require.Equal(t, expected, actual, "range [%d, %d]", lo, hi)
+		}
+	}
+
+	keys, _ := collect(cache.EntriesInFrequencyRange(2, 2))
+	require.Equal(t, []int{5, 1}, keys)
+}
+
+func TestAllIndexed(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range []IterationOrder{MRUFirst, LRUFirst} {
+		cache := NewWithOptions(6, WithIterationOrder[int, int](order))
+
+		for i := range 6 {
+			cache.Put(i, i*10)
+
+			for range i % 3 {
+				_, _ = cache.Get(i)
+			}
+		}
+
+		keys, values := collect(cache.All())
+		expected := 0
+
+		for position, entry := range cache.AllIndexed() {
+			require.Equal(t, expected, position)
+			require.Equal(t, keys[position], entry.Key)
+			require.Equal(t, values[position], entry.Value)
+
+			frequency, err := cache.GetKeyFrequency(entry.Key)
+			require.NoError(t, err)
+			require.Equal(t, frequency, entry.Frequency)
+
+			expected++
+		}
+
+		require.Equal(t, cache.Size(), expected)
+	}
+}
+
+func TestAllSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(1)
+
+	expectedKeys, expectedValues := collect(cache.All())
+	snapshot := cache.AllSnapshot()
+
+	cache.Put(4, 40)
+	cache.Put(1, 100)
+
+	keys, values := collect(snapshot)
+	require.Equal(t, expectedKeys, keys)
+	require.Equal(t, expectedValues, values)
+
+	visited := 0
+
+	for key := range cache.AllSnapshot() {
+		_, err := cache.Get(key)
+		require.NoError(t, err)
+
+		visited++
+	}
+
+	require.Equal(t, cache.Size(), visited)
+}
+
+func TestToMap(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+
+	result := cache.ToMap()
+	require.Equal(t, map[string]int{"b": 2, "c": 3, "d": 4}, result)
+
+	result["b"] = 20
+	result["e"] = 5
+	delete(result, "c")
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"d", "c", "b"}, keys)
+	require.Equal(t, []int{4, 3, 2}, values)
+
+	synchronized := NewSynchronized[string, int](2)
+	synchronized.Put("x", 1)
+	require.Equal(t, map[string]int{"x": 1}, synchronized.ToMap())
+}
+
+func TestIterationOrder(t *testing.T) {
+	t.Parallel()
+
+	mru := NewWithOptions(4, WithIterationOrder[int, int](MRUFirst))
+	lru := NewWithOptions(4, WithIterationOrder[int, int](LRUFirst))
+
+	for _, cache := range []*cacheImpl[int, int]{mru, lru} {
+		cache.Put(1, 10)
+		cache.Put(2, 20)
+		cache.Put(3, 30)
+		cache.Put(4, 40)
+		_, _ = cache.Get(4)
+		_, _ = cache.Get(2)
+	}
+
+	keys, _ := collect(mru.All())
+	require.Equal(t, []int{2, 4, 3, 1}, keys)
+
+	keys, _ = collect(lru.All())
+	require.Equal(t, []int{4, 2, 1, 3}, keys)
+
+	keys, _ = collect(lru.AllLimit(3))
+	require.Equal(t, []int{4, 2, 1}, keys)
+
+	lru.Put(5, 50)
+	mru.Put(5, 50)
+
+	keys, _ = collect(lru.All())
+	require.Equal(t, []int{4, 2, 3, 5}, keys)
+
+	keys, _ = collect(mru.All())
+	require.Equal(t, []int{2, 4, 5, 3}, keys)
+}
+
+func TestEntriesInto(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](8)
+	require.Empty(t, cache.EntriesInto(nil))
+
+	for i := range 8 {
+		cache.Put(i, i*10)
+
+		for range i % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	expected := make([]Entry[int, int], 0)
+	for _, entry := range cache.AllIndexed() {
+		expected = append(expected, entry)
+	}
+
+	require.Equal(t, expected, cache.EntriesInto(nil))
+
+	small := make([]Entry[int, int], 3)
+	require.Equal(t, expected, cache.EntriesInto(small))
+
+	buf := make([]Entry[int, int], 5, 16)
+	entries := cache.EntriesInto(buf)
+	require.Equal(t, expected, entries)
+	require.Same(t, &buf[:1][0], &entries[0])
+
+	require.NoError(t, cache.Remove(0))
+	require.Equal(t, expected[:len(expected)-1], cache.EntriesInto(entries))
+}
+
+func BenchmarkEntriesInto(b *testing.B) {
+	cache := New[int, int](1000)
+	for i := range 1000 {
+		cache.Put(i, i)
+
+		for range i % 5 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = cache.EntriesInto(nil)
+		}
+	})
+
+	b.Run("reused", func(b *testing.B) {
+		b.ReportAllocs()
+
+		var buf []Entry[int, int]
+		for i := 0; i < b.N; i++ {
+			buf = cache.EntriesInto(buf)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package lfu
+
+import "iter"
+
+// keyedCache represents LFU cache addressed by items, each item is mapped to its key by keyFn
+type keyedCache[T any, K comparable, V any] struct {
+	cache *cacheImpl[K, V]
+	keyFn func(item T) K
+}
+
+// NewKeyed initializes the cache with the given capacity whose methods accept items
+// and derive the cache key from them via keyFn. Items mapping to the same key share one entry.
+// Capacity semantics are the same as in New.
+func NewKeyed[T any, K comparable, V any](keyFn func(item T) K, capacity int) *keyedCache[T, K, V] {
+	return &keyedCache[T, K, V]{
+		cache: New[K, V](capacity),
+		keyFn: keyFn,
+	}
+}
+
+// Get returns the value stored under the key of the item, see Cache.Get.
+func (c *keyedCache[T, K, V]) Get(item T) (V, error) {
+	return c.cache.Get(c.keyFn(item))
+}
+
+// Put stores the value under the key of the item, see Cache.Put.
+func (c *keyedCache[T, K, V]) Put(item T, value V) {
+	c.cache.Put(c.keyFn(item), value)
+}
+
+// GetKeyFrequency returns the frequency of the key of the item, see Cache.GetKeyFrequency.
+func (c *keyedCache[T, K, V]) GetKeyFrequency(item T) (int, error) {
+	return c.cache.GetKeyFrequency(c.keyFn(item))
+}
+
+// All returns the iterator over derived keys and values, see Cache.All.
+func (c *keyedCache[T, K, V]) All() iter.Seq2[K, V] {
+	return c.cache.All()
+}
+
+// Size returns the cache size.
+func (c *keyedCache[T, K, V]) Size() int {
+	return c.cache.Size()
+}
+
+// Capacity returns the cache capacity.
+func (c *keyedCache[T, K, V]) Capacity() int {
+	return c.cache.Capacity()
+}
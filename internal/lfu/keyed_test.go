@@ -0,0 +1,42 @@
+package lfu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedCollision(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		email string
+		name  string
+	}
+
+	cache := NewKeyed[user, string, int](func(u user) string {
+		return strings.ToLower(u.email)
+	}, 2)
+
+	first := user{email: "Bob@Example.com", name: "Bob"}
+	second := user{email: "bob@example.com", name: "Robert"}
+
+	cache.Put(first, 1)
+	cache.Put(second, 2)
+
+	value, err := cache.Get(first)
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+
+	frequency, err := cache.GetKeyFrequency(second)
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+
+	require.Equal(t, 1, cache.Size())
+	require.Equal(t, 2, cache.Capacity())
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"bob@example.com"}, keys)
+	require.Equal(t, []int{2}, values)
+}
@@ -2,7 +2,13 @@ package lfu
 
 import (
 	"errors"
+	"io"
 	"iter"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"lfucache/internal/linkedlist"
 )
 
 var ErrKeyNotFound = errors.New("key not found")
@@ -24,7 +30,7 @@ type Cache[K comparable, V any] interface {
 	// before inserting a new item. For this problem, when there is a tie
 	// (i.e., two or more keys with the same frequency), the least recently used key would be invalidated.
 	//
-	// O(1), not amortized
+	// O(1), not amortized, unless inserting with WithInsertFrequency above 1, see there
 	Put(key K, value V)
 
 	// All returns the iterator in descending order of frequency.
@@ -50,35 +56,501 @@ type Cache[K comparable, V any] interface {
 	GetKeyFrequency(key K) (int, error)
 }
 
+// cacheData represents a single cache entry
+type cacheData[K comparable, V any] struct {
+	key       K
+	value     V
+	container *linkedlist.Node[sameFreqContainer[K, V]]
+
+	// expiresAt is the expiration deadline in Unix nanoseconds, 0 means no expiration.
+	expiresAt int64
+
+	// recency is the node of the global recency list, nil unless WithGlobalRecency is set.
+	recency *linkedlist.Node[K]
+
+	// accessedAt is the time of the last access in Unix nanoseconds, 0 unless WithAccessTimes is set.
+	accessedAt int64
+
+	// version is the cache version at the time of the last write to the entry.
+	version uint64
+
+	// hits is the number of accesses including the insertion, it is never capped.
+	hits int
+
+	// insertedAt orders entries by insertion for SecondaryFIFO.
+	insertedAt uint64
+
+	// negative marks an entry stored by PutNegative.
+	negative bool
+
+	// reserved marks a placeholder stored by Reserve until a value is put.
+	reserved bool
+}
+
+// placeholder reports whether the entry holds no real value: it is a marker stored by PutNegative
+// or a reservation stored by Reserve. Such entries are skipped when the contents are exported as key-value pairs.
+func (d *cacheData[K, V]) placeholder() bool {
+	return d.negative || d.reserved
+}
+
+// sameFreqContainer holds all entries with the same frequency.
+// Entries are ordered from the least recently used (head) to the most recently used (tail).
+type sameFreqContainer[K comparable, V any] struct {
+	freq    int
+	entries linkedlist.List[cacheData[K, V]]
+}
+
 // cacheImpl represents LFU cache implementation
-type cacheImpl[K comparable, V any] struct{}
+type cacheImpl[K comparable, V any] struct {
+	capacity int
+	// hardCapacity is the size at which Put starts evicting, see WithSoftCapacity.
+	hardCapacity int
+
+	index map[K]*linkedlist.Node[cacheData[K, V]]
+
+	// sequence holds containers in ascending order of frequency.
+	// The head is always the container with frequency 1, even when it is empty,
+	// so a new entry can be inserted in O(1).
+	sequence linkedlist.List[sameFreqContainer[K, V]]
+
+	stats Stats
+
+	// accesses is the number of touches since creation, see TotalAccesses.
+	accesses uint64
+
+	evictionVeto func(key K, value V) bool
+	pinned       map[K]struct{}
+	keyTieBreak  func(a, b K) int
+
+	clock func() time.Time
+
+	// recency orders all entries from the least recently touched (head) to the most recently touched (tail)
+	// regardless of frequency. It is nil unless WithGlobalRecency is set.
+	recency *linkedlist.List[K]
+
+	recorder io.Writer
+
+	// version is incremented on every write and removal, see Version.
+	version uint64
+
+	onEvict func(key K, value V, reason EvictReason)
+
+	logger *slog.Logger
+
+	onResize func(oldCapacity, newCapacity, evicted int)
+
+	iterationOrder IterationOrder
+
+	// maxFrequency caps entry frequencies, 0 means no cap.
+	maxFrequency int
+
+	// maxContainers bounds the number of containers, 0 means no bound.
+	maxContainers int
+
+	secondaryPolicy SecondaryPolicy
+
+	// scoreWeights replace the LFU eviction order when set, see WithScoredEviction.
+	scoreWeights *scoreWeights
+
+	rejectOnFull bool
+
+	// insertFrequency is the frequency of keys inserted by Put, see WithInsertFrequency.
+	insertFrequency int
+
+	// putFrequencyDelta is added to the frequency of a present key by Put, see WithPutFrequencyDelta.
+	putFrequencyDelta int
+
+	// sameValue reports equal values when writes are deduplicated, see WithDedupeWrites.
+	sameValue func(a, b V) bool
+
+	// insertions is the number of entries inserted so far, see cacheData.insertedAt.
+	insertions uint64
+
+	// maintenance is called every maintenanceInterval by NewSynchronized, see WithMaintenanceInterval.
+	maintenance         func(cache Cache[K, V])
+	maintenanceInterval time.Duration
+
+	// maintenanceTicker replaces time.NewTicker for the maintenance goroutine in tests.
+	maintenanceTicker func(interval time.Duration) (ticks <-chan time.Time, stop func())
+
+	// trackAccessTimes enables cacheData.accessedAt, see WithAccessTimes.
+	trackAccessTimes bool
+
+	// random is used for sampling, nil means the global source, see WithSampleRand.
+	random *rand.Rand
+}
 
 // New initializes the cache with the given capacity.
 // If no capacity is provided, the cache will use DefaultCapacity.
 func New[K comparable, V any](capacity ...int) *cacheImpl[K, V] {
-	return new(cacheImpl[K, V])
+	actualCapacity := DefaultCapacity
+	if len(capacity) > 0 {
+		actualCapacity = capacity[0]
+	}
+
+	if actualCapacity < 0 {
+		panic("lfu: capacity must not be negative")
+	}
+
+	cache := &cacheImpl[K, V]{
+		capacity:          actualCapacity,
+		index:             make(map[K]*linkedlist.Node[cacheData[K, V]]),
+		putFrequencyDelta: 1,
+		insertFrequency:   1,
+	}
+	cache.sequence.PushBack(sameFreqContainer[K, V]{freq: 1})
+
+	return cache
+}
+
+// NewNonEmpty initializes the cache with the given capacity like New,
+// but panics if the capacity is less than 1. A cache with zero capacity
+// silently drops every Put, which is rarely intended by callers requiring a real cache.
+func NewNonEmpty[K comparable, V any](capacity int) *cacheImpl[K, V] {
+	if capacity < 1 {
+		panic("lfu: capacity must be positive")
+	}
+
+	return New[K, V](capacity)
 }
 
 func (l *cacheImpl[K, V]) Get(key K) (V, error) {
-	panic("not implemented")
+	defer l.debugVerify()
+
+	if l.recorder != nil {
+		l.record("get", key)
+	}
+
+	node, ok := l.index[key]
+	if !ok || node.Value.reserved || l.expireIfNeeded(node) {
+		l.stats.Misses++
+
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	l.stats.Hits++
+	l.touch(node)
+
+	if node.Value.negative {
+		return node.Value.value, ErrNegativeCached
+	}
+
+	return node.Value.value, nil
 }
 
 func (l *cacheImpl[K, V]) Put(key K, value V) {
-	panic("not implemented")
+	l.TryPut(key, value)
+}
+
+// TryPut behaves like Put but reports whether the value was stored:
+// it returns false exactly when PutChecked fails, leaving the cache unchanged.
+//
+// O(1), not amortized, unless there are pinned entries or an eviction veto is set
+func (l *cacheImpl[K, V]) TryPut(key K, value V) bool {
+	return l.PutChecked(key, value) == nil
+}
+
+// PutChecked behaves like Put but reports why the value could not be stored.
+// An expired key is removed first, like Get does, and then inserted as a new one:
+// its old frequency and hit count are not restored.
+// Updating a present key always succeeds. Inserting a new key into a full cache fails with:
+//   - ErrCacheFull if WithRejectOnFull is set, the capacity is zero
+//     or no entry can be evicted because of the eviction veto (see WithEvictionVeto);
+//   - ErrAllPinned if no entry can be evicted because every entry is pinned (see Pin).
+//
+// On failure the cache is left unchanged.
+//
+// O(1), not amortized, unless there are pinned entries, an eviction veto is set
+// or a new key is inserted with WithInsertFrequency above 1
+func (l *cacheImpl[K, V]) PutChecked(key K, value V) error {
+	defer l.debugVerify()
+
+	if l.recorder != nil {
+		l.recordPut(key, value)
+	}
+
+	if node, ok := l.index[key]; ok && !l.expireIfNeeded(node) {
+		if node.Value.reserved {
+			node.Value.value = value
+			node.Value.reserved = false
+			l.stamp(node)
+
+			return nil
+		}
+
+		if l.sameValue != nil && !node.Value.negative && l.sameValue(node.Value.value, value) {
+			return nil
+		}
+
+		node.Value.value = value
+		node.Value.expiresAt = 0
+		node.Value.negative = false
+		l.stamp(node)
+		l.touchBy(node, l.putFrequencyDelta)
+
+		return nil
+	}
+
+	return l.insertEvicting(key, value, l.insertFrequency)
+}
+
+// insertEvicting inserts a new key at the frequency bounded by boundFrequency,
+// evicting an entry first if the cache is full. On failure the cache is left unchanged, see PutChecked.
+func (l *cacheImpl[K, V]) insertEvicting(key K, value V, freq int) error {
+	if l.Size() >= l.limit() {
+		if l.rejectOnFull {
+			return ErrCacheFull
+		}
+
+		victim := l.victim()
+		if victim == nil {
+			if l.Size() > 0 && len(l.pinned) == l.Size() {
+				return ErrAllPinned
+			}
+
+			return ErrCacheFull
+		}
+
+		l.evict(victim)
+	}
+
+	l.insertWithFrequency(key, value, l.boundFrequency(freq))
+
+	return nil
 }
 
+// All walks the live structure: modifying the cache during the iteration,
+// including by Get, is undefined behavior. Use AllSnapshot to iterate over a copy.
+// The order within a frequency can be changed by WithIterationOrder.
+// Markers stored by PutNegative and reservations stored by Reserve occupy space,
+// so they are yielded with the zero value, while ToMap, DumpText and Split skip them.
 func (l *cacheImpl[K, V]) All() iter.Seq2[K, V] {
-	panic("not implemented")
+	return func(yield func(K, V) bool) {
+		for container := l.sequence.Tail(); container != nil; container = container.Prev() {
+			if l.iterationOrder == LRUFirst {
+				for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+					if !yield(node.Value.key, node.Value.value) {
+						return
+					}
+				}
+
+				continue
+			}
+
+			for node := container.Value.entries.Tail(); node != nil; node = node.Prev() {
+				if !yield(node.Value.key, node.Value.value) {
+					return
+				}
+			}
+		}
+	}
 }
 
 func (l *cacheImpl[K, V]) Size() int {
-	panic("not implemented")
+	return len(l.index)
 }
 
 func (l *cacheImpl[K, V]) Capacity() int {
-	panic("not implemented")
+	return l.capacity
 }
 
 func (l *cacheImpl[K, V]) GetKeyFrequency(key K) (int, error) {
-	panic("not implemented")
+	node, ok := l.index[key]
+	if !ok || node.Value.reserved {
+		return 0, ErrKeyNotFound
+	}
+
+	return node.Value.container.Value.freq, nil
+}
+
+// insert places a new entry into the frequency 1 container.
+// The key must not be present in the cache.
+func (l *cacheImpl[K, V]) insert(key K, value V) *linkedlist.Node[cacheData[K, V]] {
+	root := l.sequence.Head()
+	node := root.Value.entries.PushBack(cacheData[K, V]{
+		key:        key,
+		value:      value,
+		container:  root,
+		hits:       1,
+		insertedAt: l.insertions,
+	})
+	l.insertions++
+	l.index[key] = node
+	l.markRecent(node)
+	l.stamp(node)
+
+	return node
+}
+
+// insertWithFrequency places a new entry as the most recently used one of the container
+// with the given frequency, creating the container if needed. The key must not be present in the cache.
+//
+// O(1) when the frequency is not lower than the highest one in the cache,
+// O(number of containers) in the worst case.
+func (l *cacheImpl[K, V]) insertWithFrequency(key K, value V, freq int) *linkedlist.Node[cacheData[K, V]] {
+	node := l.insert(key, value)
+	if freq <= 1 {
+		// The root container may have been empty, so the entry can exceed WithMaxContainers.
+		l.boundContainers()
+
+		return node
+	}
+
+	root := l.sequence.Head()
+	container := l.containerFor(freq)
+
+	root.Value.entries.Remove(node)
+	container.Value.entries.PushBackNode(node)
+	node.Value.container = container
+	l.boundContainers()
+
+	return node
+}
+
+// containerFor returns the container with the given frequency, creating it if needed.
+// The search starts from the highest frequency.
+func (l *cacheImpl[K, V]) containerFor(freq int) *linkedlist.Node[sameFreqContainer[K, V]] {
+	container := l.sequence.Tail()
+	for container.Value.freq > freq {
+		container = container.Prev()
+	}
+
+	if container.Value.freq == freq {
+		return container
+	}
+
+	return l.sequence.InsertAfter(sameFreqContainer[K, V]{freq: freq}, container)
+}
+
+// reset removes all entries keeping the options.
+func (l *cacheImpl[K, V]) reset() {
+	l.index = make(map[K]*linkedlist.Node[cacheData[K, V]])
+	l.sequence = linkedlist.List[sameFreqContainer[K, V]]{}
+	l.sequence.PushBack(sameFreqContainer[K, V]{freq: 1})
+	l.pinned = nil
+
+	if l.recency != nil {
+		l.recency = linkedlist.New[K]()
+	}
+}
+
+// touch increments the frequency of the entry and makes it the most recently used one.
+func (l *cacheImpl[K, V]) touch(node *linkedlist.Node[cacheData[K, V]]) {
+	current := node.Value.container
+	node.Value.hits++
+	l.accesses++
+
+	if l.maxFrequency > 0 && current.Value.freq >= l.maxFrequency {
+		current.Value.entries.Remove(node)
+		current.Value.entries.PushBackNode(node)
+		l.markRecent(node)
+
+		return
+	}
+
+	next := current.Next()
+	if next == nil || next.Value.freq != current.Value.freq+1 {
+		// The entry is alone in its container and no container holds the next frequency:
+		// raise the frequency of the container in place instead of replacing it.
+		// The root container must keep frequency 1.
+		if current != l.sequence.Head() && current.Value.entries.Len() == 1 {
+			current.Value.freq++
+			l.markRecent(node)
+
+			return
+		}
+
+		next = l.sequence.InsertAfter(sameFreqContainer[K, V]{freq: current.Value.freq + 1}, current)
+	}
+
+	current.Value.entries.Remove(node)
+	next.Value.entries.PushBackNode(node)
+	node.Value.container = next
+	l.markRecent(node)
+
+	l.dropIfEmpty(current)
+	l.boundContainers()
+}
+
+// remove deletes the entry from the cache.
+func (l *cacheImpl[K, V]) remove(node *linkedlist.Node[cacheData[K, V]]) {
+	container := node.Value.container
+
+	container.Value.entries.Remove(node)
+	delete(l.index, node.Value.key)
+	delete(l.pinned, node.Value.key)
+
+	if node.Value.recency != nil {
+		l.recency.Remove(node.Value.recency)
+	}
+
+	l.dropIfEmpty(container)
+}
+
+// evict removes the entry to make room for new ones.
+func (l *cacheImpl[K, V]) evict(node *linkedlist.Node[cacheData[K, V]]) {
+	if l.recorder != nil {
+		l.record("evict", node.Value.key)
+	}
+
+	l.remove(node)
+	l.version++
+	l.stats.Evictions++
+	l.notifyEvict(node, ReasonCapacity)
+}
+
+// dropIfEmpty removes the container from the sequence if it has no entries.
+// The frequency 1 container is never removed.
+func (l *cacheImpl[K, V]) dropIfEmpty(container *linkedlist.Node[sameFreqContainer[K, V]]) {
+	if container.Value.entries.Len() == 0 && container != l.sequence.Head() {
+		l.sequence.Remove(container)
+	}
+}
+
+// victim returns the least recently used entry among the least frequently used ones
+// skipping pinned entries and entries rejected by the eviction veto.
+// With a key tie-break the smallest key of the container is chosen instead of the least recently used one.
+// With WithScoredEviction the entry with the lowest score is chosen instead.
+// Returns nil if there is no such entry.
+//
+// O(1) without pins, the veto, the tie-break and SecondaryFIFO: only the frequency 1 container may be empty.
+// O(size) in the worst case otherwise.
+func (l *cacheImpl[K, V]) victim() *linkedlist.Node[cacheData[K, V]] {
+	if l.scoreWeights != nil {
+		return l.scoredVictim()
+	}
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		var candidate *linkedlist.Node[cacheData[K, V]]
+
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			if !l.evictable(node) {
+				continue
+			}
+
+			if !l.breaksTies() {
+				return node
+			}
+
+			if candidate == nil || l.compareTies(node, candidate) < 0 {
+				candidate = node
+			}
+		}
+
+		if candidate != nil {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+// evictable reports whether the entry may be chosen as an eviction victim.
+func (l *cacheImpl[K, V]) evictable(node *linkedlist.Node[cacheData[K, V]]) bool {
+	if _, ok := l.pinned[node.Value.key]; ok {
+		return false
+	}
+
+	return l.evictionVeto == nil || !l.evictionVeto(node.Value.key, node.Value.value)
 }
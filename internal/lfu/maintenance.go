@@ -0,0 +1,96 @@
+package lfu
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// WithMaintenanceInterval makes the cache created by NewSynchronized call fn every interval
+// from a background goroutine, e.g. to expire or re-rank entries on a schedule.
+// fn runs under the mutex with the underlying unsynchronized cache, like the callback of WithLock,
+// and must only use the view it is given.
+//
+// The goroutine runs until StopMaintenance is called or the cache becomes unreachable
+// and is collected by the garbage collector, so an abandoned cache does not leak it.
+// Other constructors panic on the option, since only NewSynchronized has a mutex to run fn under.
+// A non-positive interval disables it.
+func WithMaintenanceInterval[K comparable, V any](interval time.Duration, fn func(cache Cache[K, V])) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.maintenance = fn
+		l.maintenanceInterval = interval
+	}
+}
+
+// maintenanceLoop controls the maintenance goroutine.
+type maintenanceLoop struct {
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// StopMaintenance stops the goroutine started by WithMaintenanceInterval and waits for it to exit,
+// so fn is not called after StopMaintenance returns. It is safe to call several times,
+// and does nothing if the option was not set. It must not be called from fn.
+func (c *synchronizedCache[K, V]) StopMaintenance() {
+	if c.maintenance == nil {
+		return
+	}
+
+	c.maintenance.signalStop()
+	<-c.maintenance.done
+}
+
+// startMaintenance starts the maintenance goroutine if the option is set. The goroutine only
+// references the shared state, so the synchronized cache can become unreachable while it runs;
+// its finalizer then stops the goroutine.
+func (c *synchronizedCache[K, V]) startMaintenance() {
+	cache := c.cache
+	if cache.maintenance == nil || cache.maintenanceInterval <= 0 {
+		return
+	}
+
+	newTicker := cache.maintenanceTicker
+	if newTicker == nil {
+		newTicker = func(interval time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(interval)
+
+			return ticker.C, ticker.Stop
+		}
+	}
+
+	ticks, stopTicker := newTicker(cache.maintenanceInterval)
+
+	c.maintenance = &maintenanceLoop{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go c.lockedCache.maintain(c.maintenance, ticks, stopTicker)
+
+	runtime.SetFinalizer(c, func(c *synchronizedCache[K, V]) {
+		c.maintenance.signalStop()
+	})
+}
+
+func (s *lockedCache[K, V]) maintain(loop *maintenanceLoop, ticks <-chan time.Time, stopTicker func()) {
+	defer close(loop.done)
+	defer stopTicker()
+
+	for {
+		select {
+		case <-loop.stop:
+			return
+		case <-ticks:
+			s.mu.Lock()
+			s.cache.maintenance(s.cache)
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (m *maintenanceLoop) signalStop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
@@ -0,0 +1,96 @@
+package lfu
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func withManualTicks[K comparable, V any](clock *manualClock) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.maintenanceTicker = clock.Ticker
+	}
+}
+
+func TestMaintenanceRunsUntilStopped(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	ran := make(chan int, 10)
+	runs := 0
+
+	cache := NewSynchronized(4,
+		withManualTicks[string, int](clock),
+		WithMaintenanceInterval(time.Minute, func(view Cache[string, int]) {
+			runs++
+			view.Put("runs", runs)
+			ran <- runs
+		}),
+	)
+
+	clock.Advance(30 * time.Second)
+	require.Empty(t, ran)
+
+	for run := 1; run <= 3; run++ {
+		clock.Advance(time.Minute)
+		require.Equal(t, run, <-ran)
+	}
+
+	cache.StopMaintenance()
+
+	clock.Advance(time.Hour)
+	require.Empty(t, ran)
+
+	value, err := cache.Get("runs")
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	cache.StopMaintenance()
+}
+
+func TestMaintenanceStopsWhenCacheIsCollected(t *testing.T) {
+	t.Parallel()
+
+	// The cache is only reachable from within the helper.
+	abandon := func() *maintenanceLoop {
+		cache := NewSynchronized(4,
+			withManualTicks[int, int](newManualClock()),
+			WithMaintenanceInterval(time.Minute, func(Cache[int, int]) {}),
+		)
+
+		return cache.maintenance
+	}
+
+	loop := abandon()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+
+		select {
+		case <-loop.done:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestMaintenanceDisabled(t *testing.T) {
+	t.Parallel()
+
+	fn := func(Cache[int, int]) {
+		require.Fail(t, "maintenance must not run")
+	}
+
+	cache := NewSynchronized(2, WithMaintenanceInterval(0, fn))
+	require.Nil(t, cache.maintenance)
+	cache.StopMaintenance()
+
+	NewSynchronized[int, int](2).StopMaintenance()
+
+	require.Panics(t, func() {
+		NewWithOptions(2, WithMaintenanceInterval(time.Minute, fn))
+	})
+}
@@ -0,0 +1,40 @@
+package lfu
+
+// Memoize returns a function caching the results of fn in an LFU cache with the given capacity.
+// On a hit the cached result is returned and its frequency is incremented,
+// on a miss fn is called and its result is stored. fn is called again for a key
+// only after its result was evicted.
+//
+// The returned function is not safe for concurrent use, see MemoizeSync.
+func Memoize[K comparable, V any](capacity int, fn func(K) V) func(K) V {
+	cache := New[K, V](capacity)
+
+	return func(key K) V {
+		if value, err := cache.Get(key); err == nil {
+			return value
+		}
+
+		value := fn(key)
+		cache.Put(key, value)
+
+		return value
+	}
+}
+
+// MemoizeSync is Memoize safe for concurrent use. fn is called without holding
+// the cache lock, so concurrent misses of the same key may call fn more than once;
+// the last result stored wins.
+func MemoizeSync[K comparable, V any](capacity int, fn func(K) V) func(K) V {
+	cache := NewSynchronized[K, V](capacity)
+
+	return func(key K) V {
+		if value, err := cache.Get(key); err == nil {
+			return value
+		}
+
+		value := fn(key)
+		cache.Put(key, value)
+
+		return value
+	}
+}
@@ -0,0 +1,64 @@
+package lfu
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+
+	calls := make(map[int]int)
+	square := Memoize(2, func(x int) int {
+		calls[x]++
+		return x * x
+	})
+
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 4, square(2))
+	require.Equal(t, 9, square(3))
+	require.Equal(t, 9, square(3))
+	require.Equal(t, 4, square(2))
+	require.Equal(t, map[int]int{2: 1, 3: 1}, calls)
+
+	require.Equal(t, 16, square(4))
+	require.Equal(t, 9, square(3))
+	require.Equal(t, map[int]int{2: 1, 3: 2, 4: 1}, calls)
+}
+
+func TestMemoizeSync(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	square := MemoizeSync(10, func(x int) int {
+		calls.Add(1)
+		return x * x
+	})
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, i*i, square(i))
+	}
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+				if square(i%10) != (i%10)*(i%10) {
+					t.Error("unexpected result")
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, int64(10), calls.Load())
+}
@@ -0,0 +1,53 @@
+package lfu
+
+import (
+	"unsafe"
+
+	"lfucache/internal/linkedlist"
+)
+
+// ApproxMemoryBytes estimates the number of bytes held by the cache.
+//
+// The estimate is the sum of:
+//   - the cache struct itself;
+//   - a list node per frequency container;
+//   - per entry: its list node (which stores the key and the value inline),
+//     an index map slot holding the key and a pointer, inflated by a quarter
+//     for partially filled map buckets, and a global recency node if WithGlobalRecency is set;
+//   - per entry: keySize(key) + valueSize(value), the bytes referenced by the key and the value
+//     outside of their inline representation, e.g. contents of strings and slices.
+//     Either function may be nil, meaning zero.
+//
+// Allocator size classes, map growth state and memory referenced by options are ignored,
+// so the result is only suitable for capacity planning, not accounting.
+//
+// O(size)
+func (l *cacheImpl[K, V]) ApproxMemoryBytes(keySize func(K) int, valueSize func(V) int) int64 {
+	var (
+		key     K
+		pointer uintptr
+	)
+
+	entryBytes := int64(unsafe.Sizeof(linkedlist.Node[cacheData[K, V]]{}))
+	entryBytes += (int64(unsafe.Sizeof(key)+unsafe.Sizeof(pointer)) + 1) * 5 / 4
+
+	if l.recency != nil {
+		entryBytes += int64(unsafe.Sizeof(linkedlist.Node[K]{}))
+	}
+
+	total := int64(unsafe.Sizeof(*l))
+	total += int64(l.sequence.Len()) * int64(unsafe.Sizeof(linkedlist.Node[sameFreqContainer[K, V]]{}))
+	total += int64(l.Size()) * entryBytes
+
+	for _, node := range l.index {
+		if keySize != nil {
+			total += int64(keySize(node.Value.key))
+		}
+
+		if valueSize != nil {
+			total += int64(valueSize(node.Value.value))
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,37 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproxMemoryBytesScalesLinearly(t *testing.T) {
+	t.Parallel()
+
+	keySize := func(string) int { return 16 }
+	valueSize := func([]byte) int { return 64 }
+
+	estimate := func(n int) int64 {
+		cache := New[string, []byte](1000)
+		for i := range n {
+			cache.Put(string(rune('a'+i%26))+string(rune(i)), make([]byte, 64))
+		}
+
+		return cache.ApproxMemoryBytes(keySize, valueSize)
+	}
+
+	empty := estimate(0)
+	perEntry := estimate(1) - empty
+
+	require.Positive(t, empty)
+	require.Greater(t, perEntry, int64(80))
+
+	for _, n := range []int{10, 100, 500} {
+		require.Equal(t, empty+int64(n)*perEntry, estimate(n))
+	}
+
+	withoutSizes := New[string, []byte](10)
+	withoutSizes.Put("a", nil)
+	require.Equal(t, perEntry-80, withoutSizes.ApproxMemoryBytes(nil, nil)-empty)
+}
@@ -0,0 +1,94 @@
+package lfu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"lfucache/internal/lfumodel"
+)
+
+// ErrModelMismatch is returned by ModelCheck when the cache disagrees with the reference model.
+var ErrModelMismatch = errors.New("cache disagrees with the reference model")
+
+// ModelCheck applies the operations both to a new cache with the given capacity and to
+// the brute-force reference implementation from lfumodel. After every operation it compares
+// results of Get and Remove, Size, keys and values yielded by All in order and
+// the frequency of every key. The first disagreement is returned wrapping ErrModelMismatch.
+// Operations of unknown kind are skipped.
+//
+// It is intended for fuzzing, see FuzzModelCheck.
+//
+// O(number of operations * capacity * log(capacity))
+func ModelCheck[K comparable, V comparable](capacity int, ops []Op[K, V]) error {
+	cache := New[K, V](capacity)
+	model := lfumodel.New[K, V](capacity)
+
+	for i, op := range ops {
+		if err := applyToBoth(cache, model, op); err != nil {
+			return fmt.Errorf("op %d %+v: %w", i, op, err)
+		}
+
+		if err := compareWithModel(cache, model); err != nil {
+			return fmt.Errorf("after op %d %+v: %w", i, op, err)
+		}
+	}
+
+	return nil
+}
+
+func applyToBoth[K comparable, V comparable](cache *cacheImpl[K, V], model *lfumodel.Model[K, V], op Op[K, V]) error {
+	switch op.Kind {
+	case OpPut:
+		cache.Put(op.Key, op.Value)
+		model.Put(op.Key, op.Value)
+	case OpGet:
+		value, err := cache.Get(op.Key)
+		expected, expectedErr := model.Get(op.Key)
+
+		if (err == nil) != (expectedErr == nil) || value != expected {
+			return fmt.Errorf("%w: Get returned %v, %v, model returned %v, %v",
+				ErrModelMismatch, value, err, expected, expectedErr)
+		}
+	case OpRemove:
+		err := cache.Remove(op.Key)
+		expectedErr := model.Remove(op.Key)
+
+		if (err == nil) != (expectedErr == nil) {
+			return fmt.Errorf("%w: Remove returned %v, model returned %v", ErrModelMismatch, err, expectedErr)
+		}
+	}
+
+	return nil
+}
+
+func compareWithModel[K comparable, V comparable](cache *cacheImpl[K, V], model *lfumodel.Model[K, V]) error {
+	if cache.Size() != model.Size() {
+		return fmt.Errorf("%w: size %d, model size %d", ErrModelMismatch, cache.Size(), model.Size())
+	}
+
+	keys := make([]K, 0, cache.Size())
+	for key, value := range cache.All() {
+		keys = append(keys, key)
+
+		expected, _ := model.Peek(key)
+		if value != expected {
+			return fmt.Errorf("%w: value of %v is %v, model value %v", ErrModelMismatch, key, value, expected)
+		}
+	}
+
+	if expected := model.Keys(); !slices.Equal(keys, expected) {
+		return fmt.Errorf("%w: All yields %v, model yields %v", ErrModelMismatch, keys, expected)
+	}
+
+	for _, key := range keys {
+		frequency, _ := cache.GetKeyFrequency(key)
+		expected, _ := model.GetKeyFrequency(key)
+
+		if frequency != expected {
+			return fmt.Errorf("%w: frequency of %v is %d, model frequency %d", ErrModelMismatch, key, frequency, expected)
+		}
+	}
+
+	return nil
+}
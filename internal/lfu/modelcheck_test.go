@@ -0,0 +1,75 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"lfucache/internal/lfumodel"
+)
+
+// opsFromBytes decodes the fuzzer input: the first byte selects the capacity,
+// every following pair of bytes is an operation kind and a key.
+func opsFromBytes(data []byte) (int, []Op[int, int]) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	capacity := int(data[0] % 6)
+	ops := make([]Op[int, int], 0, len(data)/2)
+
+	for i := 1; i+1 < len(data); i += 2 {
+		ops = append(ops, Op[int, int]{
+			Kind:  OpKind(data[i]%3) + OpPut,
+			Key:   int(data[i+1] % 10),
+			Value: i,
+		})
+	}
+
+	return capacity, ops
+}
+
+func TestModelCheck(t *testing.T) {
+	t.Parallel()
+
+	ops := []Op[string, int]{
+		{Kind: OpPut, Key: "a", Value: 1},
+		{Kind: OpPut, Key: "b", Value: 2},
+		{Kind: OpGet, Key: "a"},
+		{Kind: OpPut, Key: "c", Value: 3},
+		{Kind: OpGet, Key: "b"},
+		{Kind: OpRemove, Key: "a"},
+		{Kind: OpRemove, Key: "a"},
+		{Kind: OpPut, Key: "c", Value: 30},
+		{Kind: OpPut, Key: "d", Value: 4},
+		{Kind: OpPut, Key: "e", Value: 5},
+	}
+
+	for capacity := range 4 {
+		require.NoError(t, ModelCheck(capacity, ops))
+	}
+}
+
+func TestModelCheckDetectsMismatch(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+	cache.touch(cache.index["a"])
+
+	model := lfumodel.New[string, int](2)
+	model.Put("a", 1)
+
+	err := compareWithModel(cache, model)
+	require.ErrorIs(t, err, ErrModelMismatch)
+}
+
+func FuzzModelCheck(f *testing.F) {
+	f.Add([]byte{2, 0, 1, 0, 2, 1, 1, 0, 3, 2, 0})
+	f.Add([]byte{3, 0, 0, 0, 1, 0, 2, 0, 3, 1, 0, 0, 4, 2, 1})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		capacity, ops := opsFromBytes(data)
+		require.NoError(t, ModelCheck(capacity, ops))
+	})
+}
@@ -0,0 +1,191 @@
+package lfu
+
+import "errors"
+
+var ErrKeyExists = errors.New("key already exists")
+
+// Remove deletes the entry of the key from the cache.
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Remove(key K) error {
+	defer l.debugVerify()
+
+	if l.recorder != nil {
+		l.record("remove", key)
+	}
+
+	node, ok := l.index[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	l.remove(node)
+	l.version++
+	l.notifyEvict(node, ReasonManual)
+
+	return nil
+}
+
+// Modify replaces the value of the key with the result of f, which receives the current value
+// and whether the key is present. An absent key (including an expired one, a negative marker
+// or a reservation) gets f(zero value, false) inserted. Either way the write is a single access,
+// exactly like Put; reading the current value does not count as another one.
+// f must not modify the cache.
+//
+// O(1), not amortized, plus the cost of f
+func (l *cacheImpl[K, V]) Modify(key K, f func(value V, ok bool) V) {
+	node, ok := l.index[key]
+	if ok && (node.Value.negative || node.Value.reserved || l.expireIfNeeded(node)) {
+		ok = false
+	}
+
+	if !ok {
+		var zero V
+
+		l.Put(key, f(zero, false))
+
+		return
+	}
+
+	l.Put(key, f(node.Value.value, true))
+}
+
+// RemoveMany removes every present key and returns the number of removed entries.
+// Absent keys are ignored. Each removal is reported to the OnEvict callback with ReasonManual.
+//
+// O(len(keys)), not amortized
+func (l *cacheImpl[K, V]) RemoveMany(keys []K) int {
+	removed := 0
+
+	for _, key := range keys {
+		if l.Remove(key) == nil {
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Rekey moves the entry from the old key to the new one keeping its value, frequency,
+// recency, expiration and pin. Rekeying a key to itself is a no-op.
+//
+// Returns ErrKeyNotFound if the old key is not present
+// and ErrKeyExists if the new key is already present, nothing is overwritten.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Rekey(oldKey, newKey K) error {
+	defer l.debugVerify()
+
+	node, ok := l.index[oldKey]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	if oldKey == newKey {
+		return nil
+	}
+
+	if _, ok := l.index[newKey]; ok {
+		return ErrKeyExists
+	}
+
+	delete(l.index, oldKey)
+	l.index[newKey] = node
+	node.Value.key = newKey
+	l.stamp(node)
+
+	if node.Value.recency != nil {
+		node.Value.recency.Value = newKey
+	}
+
+	if _, ok := l.pinned[oldKey]; ok {
+		delete(l.pinned, oldKey)
+		l.pinned[newKey] = struct{}{}
+	}
+
+	return nil
+}
+
+// SwapFrequencies exchanges the frequencies of two entries keeping their values.
+// Each entry becomes the most recently used one among the entries of its new frequency.
+// Swapping entries of the same frequency or a key with itself is a no-op.
+//
+// Returns ErrKeyNotFound if either key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) SwapFrequencies(a, b K) error {
+	defer l.debugVerify()
+
+	nodeA, ok := l.index[a]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	nodeB, ok := l.index[b]
+	if !ok {
+		return ErrKeyNotFound
+	}
+
+	containerA, containerB := nodeA.Value.container, nodeB.Value.container
+	if containerA == containerB {
+		return nil
+	}
+
+	// Both containers get one entry back for the one they lose, so neither becomes empty.
+	containerA.Value.entries.Remove(nodeA)
+	containerB.Value.entries.Remove(nodeB)
+
+	containerB.Value.entries.PushBackNode(nodeA)
+	nodeA.Value.container = containerB
+
+	containerA.Value.entries.PushBackNode(nodeB)
+	nodeB.Value.container = containerA
+
+	return nil
+}
+
+// ReplaceGroup removes every entry whose key satisfies selector and then puts every entry of newEntries,
+// e.g. to refresh all keys sharing a prefix. Removals happen in eviction order and are reported
+// to the OnEvict callback with ReasonManual. New entries are inserted in the given order,
+// evicting other entries, or each other, if they do not fit: within a frequency an earlier entry
+// is older and is evicted first. An entry with a positive Frequency is inserted at that frequency,
+// capped by WithMaxFrequency, the others start at the insert frequency like Put, 1 by default.
+// A key of newEntries that survives the removal is updated as by Put, ignoring its Frequency.
+// selector must not modify the cache.
+//
+// O(size + len(newEntries) * number of containers), not amortized
+func (l *cacheImpl[K, V]) ReplaceGroup(selector func(key K) bool, newEntries []Entry[K, V]) {
+	defer l.debugVerify()
+
+	group := make([]K, 0)
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			if selector(node.Value.key) {
+				group = append(group, node.Value.key)
+			}
+		}
+	}
+
+	l.RemoveMany(group)
+
+	for _, entry := range newEntries {
+		node, ok := l.index[entry.Key]
+		if ok && l.expireIfNeeded(node) {
+			ok = false
+		}
+
+		if ok || entry.Frequency <= 0 {
+			l.Put(entry.Key, entry.Value)
+			continue
+		}
+
+		if l.recorder != nil {
+			l.recordPut(entry.Key, entry.Value)
+		}
+
+		_ = l.insertEvicting(entry.Key, entry.Value, entry.Frequency)
+	}
+}
@@ -0,0 +1,185 @@
+package lfu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemove(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(2)
+
+	require.NoError(t, cache.Remove(2))
+	require.ErrorIs(t, cache.Remove(2), ErrKeyNotFound)
+	require.Equal(t, 2, cache.Size())
+
+	_, err := cache.Get(2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{3, 1}, keys)
+	require.Equal(t, 1, cache.ContainerCount())
+
+	cache.Put(4, 40)
+	cache.Put(5, 50)
+	require.Equal(t, 3, cache.Size())
+	require.Equal(t, uint64(1), cache.Stats().Evictions)
+}
+
+func TestRekey(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithGlobalRecency[string, int]())
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("b")
+	require.NoError(t, cache.Pin("b"))
+
+	frequency, err := cache.GetKeyFrequency("b")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Rekey("b", "B"))
+
+	_, err = cache.GetKeyFrequency("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	newFrequency, err := cache.GetKeyFrequency("B")
+	require.NoError(t, err)
+	require.Equal(t, frequency, newFrequency)
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"B", "c", "a"}, keys)
+	require.Equal(t, []int{2, 3, 1}, values)
+	require.Equal(t, []string{"B", "c", "a"}, cache.RecentKeys(3))
+	require.True(t, cache.IsPinned("B"))
+	require.False(t, cache.IsPinned("b"))
+}
+
+func TestRekeyErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	require.ErrorIs(t, cache.Rekey("x", "y"), ErrKeyNotFound)
+	require.ErrorIs(t, cache.Rekey("a", "b"), ErrKeyExists)
+	require.NoError(t, cache.Rekey("a", "a"))
+
+	value, err := cache.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+}
+
+func TestSwapFrequencies(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+
+	for range 3 {
+		_, _ = cache.Get("c")
+	}
+
+	_, _ = cache.Get("d")
+
+	require.NoError(t, cache.SwapFrequencies("a", "c"))
+
+	require.Equal(t, map[string]int{"a": 4, "b": 1, "c": 1, "d": 2}, cache.FrequenciesOf([]string{"a", "b", "c", "d"}))
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"a", "d", "c", "b"}, keys)
+	require.Equal(t, []int{1, 4, 3, 2}, values)
+	require.Equal(t, 3, cache.ContainerCount())
+
+	require.NoError(t, cache.SwapFrequencies("b", "c"))
+	require.ErrorIs(t, cache.SwapFrequencies("a", "x"), ErrKeyNotFound)
+	require.ErrorIs(t, cache.SwapFrequencies("x", "a"), ErrKeyNotFound)
+
+	keys, _ = collect(cache.All())
+	require.Equal(t, []string{"a", "d", "c", "b"}, keys)
+}
+
+func TestReplaceGroup(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(5, WithOnEvict(func(key string, _ int, reason EvictReason) {
+		require.Equal(t, ReasonManual, reason, "unexpected eviction of %s", key)
+	}))
+
+	cache.Put("user:1", 1)
+	cache.Put("user:2", 2)
+	cache.Put("post:1", 10)
+	_, _ = cache.Get("user:1")
+	_, _ = cache.Get("post:1")
+
+	cache.ReplaceGroup(func(key string) bool {
+		return strings.HasPrefix(key, "user:")
+	}, []Entry[string, int]{{Key: "user:2", Value: 20}, {Key: "user:3", Value: 30}})
+
+	require.Equal(t, map[string]int{"post:1": 10, "user:2": 20, "user:3": 30}, cache.ToMap())
+	require.Equal(t, map[string]int{"post:1": 2, "user:2": 1, "user:3": 1},
+		cache.FrequenciesOf([]string{"post:1", "user:2", "user:3"}))
+}
+
+func TestReplaceGroupFrequencies(t *testing.T) {
+	t.Parallel()
+
+	removed := make([]string, 0)
+	cache := NewWithOptions(4, WithMaxFrequency[string, int](5), WithOnEvict(func(key string, _ int, _ EvictReason) {
+		removed = append(removed, key)
+	}))
+
+	cache.Put("user:1", 1)
+	cache.Put("user:2", 2)
+	cache.Put("user:3", 3)
+	_, _ = cache.Get("user:1")
+
+	cache.ReplaceGroup(func(key string) bool {
+		return key != "user:3"
+	}, []Entry[string, int]{
+		{Key: "user:3", Value: 30, Frequency: 4},
+		{Key: "user:4", Value: 40, Frequency: 3},
+		{Key: "user:5", Value: 50, Frequency: 10},
+		{Key: "user:6", Value: 60},
+	})
+
+	require.Equal(t, []string{"user:2", "user:1"}, removed)
+	require.Equal(t, map[string]int{"user:3": 2, "user:4": 3, "user:5": 5, "user:6": 1},
+		cache.FrequenciesOf([]string{"user:3", "user:4", "user:5", "user:6"}))
+	require.NoError(t, cache.Verify())
+}
+
+func TestReplaceGroupEvicts(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	_, _ = cache.Get(3)
+
+	cache.ReplaceGroup(func(key int) bool {
+		return key == 1
+	}, []Entry[int, int]{{Key: 10, Value: 10}, {Key: 11, Value: 11}, {Key: 12, Value: 12}})
+
+	require.Equal(t, map[int]int{3: 3, 11: 11, 12: 12}, cache.ToMap())
+	require.NoError(t, cache.Verify())
+}
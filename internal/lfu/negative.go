@@ -0,0 +1,31 @@
+package lfu
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNegativeCached is returned by Get for a key stored by PutNegative whose marker has not expired.
+var ErrNegativeCached = errors.New("key is cached as absent")
+
+// PutNegative stores a marker that the key is known to be absent upstream,
+// so callers can skip recomputing it. Until the marker expires after ttl,
+// Get counts a hit and returns the zero value with ErrNegativeCached; afterwards
+// the key is a usual miss. A non-positive ttl stores the marker without expiration.
+//
+// The marker is an ordinary entry with the zero value: it occupies space, is yielded by All
+// and is evicted by the usual LFU rules. Storing a value for the key by Put replaces the marker.
+// It is not a value, so ToMap, DumpText and Split skip it, while EncodeGob keeps it a marker.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) PutNegative(key K, ttl time.Duration) {
+	defer l.debugVerify()
+
+	var zero V
+
+	l.PutWithTTL(key, zero, ttl)
+
+	if node, ok := l.index[key]; ok {
+		node.Value.negative = true
+	}
+}
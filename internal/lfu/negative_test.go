@@ -0,0 +1,129 @@
+package lfu
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegativeCachingUntilExpiration(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[string, int](clock.Now))
+
+	computations := 0
+	load := func(key string) (int, error) {
+		value, err := cache.Get(key)
+		if err == nil || errors.Is(err, ErrNegativeCached) {
+			return value, err
+		}
+
+		computations++
+		cache.PutNegative(key, time.Minute)
+
+		return 0, ErrNegativeCached
+	}
+
+	for range 3 {
+		_, err := load("missing")
+		require.ErrorIs(t, err, ErrNegativeCached)
+		clock.Advance(20 * time.Second)
+	}
+
+	require.Equal(t, 1, computations)
+	require.Equal(t, Stats{Hits: 2, Misses: 1}, cache.Stats())
+
+	_, err := cache.Get("missing")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = load("missing")
+	require.ErrorIs(t, err, ErrNegativeCached)
+	require.Equal(t, 2, computations)
+}
+
+func TestNegativeEntryReplacedAndEvicted(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+
+	cache.PutNegative("a", 0)
+	cache.Put("a", 1)
+
+	value, err := cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	cache.PutNegative("b", 0)
+	require.Equal(t, 2, cache.Size())
+
+	cache.Put("c", 3)
+
+	_, err = cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestNegativeMarkerExports(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.PutNegative("n", 0)
+
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, cache.ToMap())
+
+	var dump strings.Builder
+	require.NoError(t, cache.DumpText(&dump, func(key string, value int) string {
+		return key + "=" + strconv.Itoa(value)
+	}))
+	require.Equal(t, "b=2\na=1\n", dump.String())
+
+	hot, cold := cache.Split(0.5)
+	require.Equal(t, map[string]int{"b": 2}, hot.ToMap())
+	require.Equal(t, map[string]int{"a": 1}, cold.ToMap())
+
+	var buf bytes.Buffer
+	require.NoError(t, cache.EncodeGob(&buf))
+
+	decoded := New[string, int](4)
+	require.NoError(t, decoded.DecodeGob(&buf))
+	require.Equal(t, 3, decoded.Size())
+
+	_, err := decoded.Get("n")
+	require.ErrorIs(t, err, ErrNegativeCached)
+
+	value, err := decoded.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+}
+
+func TestNegativeMarkerPeekAndStaleRead(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[string, int](clock.Now))
+	cache.PutNegative("n", time.Minute)
+
+	_, err := cache.Peek("n")
+	require.ErrorIs(t, err, ErrNegativeCached)
+
+	_, stale, err := cache.GetAllowStale("n")
+	require.ErrorIs(t, err, ErrNegativeCached)
+	require.False(t, stale)
+
+	clock.Advance(time.Hour)
+
+	_, err = cache.Peek("n")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, stale, err = cache.GetAllowStale("n")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, stale)
+	require.Equal(t, 0, cache.Size())
+}
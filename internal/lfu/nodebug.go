@@ -0,0 +1,9 @@
+//go:build !lfudebug
+
+package lfu
+
+// debugEnabled reports whether the cache is built with the lfudebug tag.
+const debugEnabled = false
+
+// debugVerify does nothing without the lfudebug build tag, see debug.go.
+func (l *cacheImpl[K, V]) debugVerify() {}
@@ -0,0 +1,44 @@
+package lfu
+
+// Number is a constraint permitting any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// numericCache represents LFU cache of numeric values supporting in-place arithmetic
+type numericCache[K comparable, V Number] struct {
+	*cacheImpl[K, V]
+}
+
+// NewNumeric initializes the cache of numeric values with the given capacity.
+// Capacity semantics are the same as in New.
+func NewNumeric[K comparable, V Number](capacity ...int) *numericCache[K, V] {
+	return &numericCache[K, V]{cacheImpl: New[K, V](capacity...)}
+}
+
+// Increment adds delta to the value of the key and returns the new value.
+// An absent key is treated as holding zero and is inserted with the value delta,
+// evicting an entry if the cache is full. Expired entries, markers stored by PutNegative
+// and reservations stored by Reserve count as absent.
+// The new value is stored by Put, so the frequency is incremented as by Put,
+// the expiration deadline is cleared and the write is recorded.
+//
+// If the key is absent and cannot be inserted (see TryPut), delta is returned
+// but nothing is stored.
+//
+// O(1), not amortized
+func (c *numericCache[K, V]) Increment(key K, delta V) V {
+	defer c.debugVerify()
+
+	var current V
+	if node, ok := c.index[key]; ok && !c.expireIfNeeded(node) && !node.Value.placeholder() {
+		current = node.Value.value
+	}
+
+	value := current + delta
+	c.TryPut(key, value)
+
+	return value
+}
@@ -0,0 +1,90 @@
+package lfu
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrement(t *testing.T) {
+	t.Parallel()
+
+	cache := NewNumeric[string, int](2)
+
+	require.Equal(t, 5, cache.Increment("a", 5))
+	require.Equal(t, 3, cache.Increment("a", -2))
+	require.Equal(t, 1, cache.Increment("b", 1))
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+
+	require.Equal(t, 7, cache.Increment("c", 7))
+
+	_, err = cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	value, err := cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	value, err = cache.Get("c")
+	require.NoError(t, err)
+	require.Equal(t, 7, value)
+}
+
+func TestIncrementFloat(t *testing.T) {
+	t.Parallel()
+
+	cache := NewNumeric[int, float64]()
+
+	cache.Increment(1, 0.5)
+	require.InDelta(t, 1.75, cache.Increment(1, 1.25), 1e-9)
+	require.Equal(t, DefaultCapacity, cache.Capacity())
+}
+
+func TestIncrementTreatsStaleEntriesAsAbsent(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+
+	var trace strings.Builder
+
+	cache := &numericCache[string, int]{cacheImpl: NewWithOptions(4,
+		WithClock[string, int](clock.Now),
+		WithPutFrequencyDelta[string, int](2),
+		WithRecorder[string, int](&trace),
+	)}
+
+	cache.PutWithTTL("expired", 10, time.Minute)
+	clock.Advance(time.Minute)
+	require.Equal(t, 1, cache.Increment("expired", 1))
+
+	value, err := cache.Get("expired")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	cache.PutNegative("negative", 0)
+	require.Equal(t, 2, cache.Increment("negative", 2))
+
+	value, err = cache.Get("negative")
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+
+	require.True(t, cache.Reserve("reserved"))
+	require.Equal(t, 3, cache.Increment("reserved", 3))
+
+	value, err = cache.Get("reserved")
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	cache.Put("live", 1)
+	require.Equal(t, 5, cache.Increment("live", 4))
+
+	frequency, err := cache.GetKeyFrequency("live")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+	require.Contains(t, trace.String(), "put \"live\" \"5\"\n")
+}
@@ -0,0 +1,49 @@
+package lfu
+
+// Option configures the cache created by NewWithOptions.
+type Option[K comparable, V any] func(*cacheImpl[K, V])
+
+// NewWithOptions initializes the cache with the given capacity and applies the options in order.
+//
+// Panics if WithMaintenanceInterval is given: only NewSynchronized has a mutex to run it under.
+func NewWithOptions[K comparable, V any](capacity int, options ...Option[K, V]) *cacheImpl[K, V] {
+	cache := newWithOptions(capacity, options...)
+	if cache.maintenance != nil {
+		panic("lfu: WithMaintenanceInterval requires NewSynchronized")
+	}
+
+	return cache
+}
+
+func newWithOptions[K comparable, V any](capacity int, options ...Option[K, V]) *cacheImpl[K, V] {
+	cache := New[K, V](capacity)
+
+	for _, option := range options {
+		option(cache)
+	}
+
+	return cache
+}
+
+// WithEvictionVeto sets a callback consulted before an entry is evicted to make room for a new one.
+// If the callback returns true the candidate is kept and the next one in eviction order is tried:
+// older entries first, lower frequencies first.
+//
+// If every entry is vetoed, the new entry is not inserted. Put silently drops it,
+// TryPut reports it by returning false.
+func WithEvictionVeto[K comparable, V any](veto func(key K, value V) bool) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.evictionVeto = veto
+	}
+}
+
+// WithKeyTieBreak makes eviction deterministic: among the entries with the lowest frequency
+// the smallest key according to cmp is evicted instead of the least recently used one.
+// cmp returns a negative number when a < b, zero when a == b and a positive number when a > b.
+//
+// Eviction becomes O(container size) since the whole lowest frequency container is scanned.
+func WithKeyTieBreak[K comparable, V any](cmp func(a, b K) int) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.keyTieBreak = cmp
+	}
+}
@@ -0,0 +1,80 @@
+package lfu
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvictionVeto(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithEvictionVeto(func(key int, _ int) bool {
+		return key == 1
+	}))
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(3)
+
+	cache.Put(4, 40)
+
+	_, err := cache.Get(1)
+	require.NoError(t, err)
+
+	_, err = cache.Get(2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	require.Equal(t, 3, cache.Size())
+}
+
+func TestEvictionVetoRejectsWhenEverythingVetoed(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithEvictionVeto(func(int, int) bool {
+		return true
+	}))
+
+	require.True(t, cache.TryPut(1, 10))
+	require.True(t, cache.TryPut(2, 20))
+	require.False(t, cache.TryPut(3, 30))
+
+	cache.Put(4, 40)
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{2, 1}, keys)
+	require.Equal(t, uint64(0), cache.Stats().Evictions)
+
+	require.True(t, cache.TryPut(1, 100))
+	value, err := cache.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 100, value)
+}
+
+func TestKeyTieBreak(t *testing.T) {
+	t.Parallel()
+
+	orders := [][]int{
+		{1, 2, 3, 4},
+		{4, 3, 2, 1},
+		{3, 1, 4, 2},
+	}
+
+	for _, order := range orders {
+		cache := NewWithOptions(4, WithKeyTieBreak[int, int](cmp.Compare[int]))
+
+		for _, key := range order {
+			cache.Put(key, key)
+		}
+
+		_, _ = cache.Get(1)
+
+		cache.Put(5, 5)
+		cache.Put(6, 6)
+
+		keys, _ := collect(cache.All())
+		require.ElementsMatch(t, []int{1, 4, 5, 6}, keys, "order %v", order)
+	}
+}
@@ -0,0 +1,51 @@
+package lfu
+
+// Pin protects the entry from eviction until it is unpinned.
+// Pinned entries keep their frequency and recency and are skipped when a victim is chosen.
+//
+// The size never exceeds the capacity: if the cache is full and every entry is pinned,
+// a new key is not inserted (see TryPut).
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Pin(key K) error {
+	defer l.debugVerify()
+
+	if _, ok := l.index[key]; !ok {
+		return ErrKeyNotFound
+	}
+
+	if l.pinned == nil {
+		l.pinned = make(map[K]struct{})
+	}
+
+	l.pinned[key] = struct{}{}
+
+	return nil
+}
+
+// Unpin makes the pinned entry evictable again. Unpinning an entry that is not pinned is a no-op.
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Unpin(key K) error {
+	defer l.debugVerify()
+
+	if _, ok := l.index[key]; !ok {
+		return ErrKeyNotFound
+	}
+
+	delete(l.pinned, key)
+
+	return nil
+}
+
+// IsPinned reports whether the key is present and pinned.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) IsPinned(key K) bool {
+	_, ok := l.pinned[key]
+	return ok
+}
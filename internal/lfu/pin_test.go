@@ -0,0 +1,66 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedSurviveInsertionPressure(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](4)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	require.NoError(t, cache.Pin(1))
+	require.NoError(t, cache.Pin(2))
+
+	for i := 100; i < 200; i++ {
+		cache.Put(i, i)
+	}
+
+	for _, key := range []int{1, 2} {
+		_, err := cache.Get(key)
+		require.NoError(t, err)
+	}
+
+	for i := 100; i < 198; i++ {
+		_, err := cache.Get(i)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	}
+
+	require.Equal(t, 4, cache.Size())
+	require.Equal(t, uint64(98), cache.Stats().Evictions)
+}
+
+func TestPutRejectedWhenAllPinned(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	require.NoError(t, cache.Pin(1))
+	require.NoError(t, cache.Pin(2))
+
+	require.False(t, cache.TryPut(3, 30))
+	require.Equal(t, 2, cache.Size())
+
+	require.NoError(t, cache.Unpin(1))
+	require.False(t, cache.IsPinned(1))
+	require.True(t, cache.TryPut(3, 30))
+
+	_, err := cache.Get(1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestPinMissingKey(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	require.ErrorIs(t, cache.Pin(1), ErrKeyNotFound)
+	require.ErrorIs(t, cache.Unpin(1), ErrKeyNotFound)
+	require.False(t, cache.IsPinned(1))
+}
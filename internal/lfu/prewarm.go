@@ -0,0 +1,29 @@
+package lfu
+
+// NewPrewarmed initializes the cache with the given capacity and fills it with the entries,
+// each at its recorded frequency. Entries are inserted in the given order, so within
+// the same frequency an earlier entry is older and is evicted first.
+// Frequencies lower than 1 are treated as 1.
+//
+// Panics if the capacity is negative, if there are more entries than the capacity
+// or if a key occurs more than once.
+//
+// O(len(entries)) when entries are sorted by ascending frequency,
+// O(len(entries) * number of distinct frequencies) in the worst case.
+func NewPrewarmed[K comparable, V any](capacity int, entries []Entry[K, V]) *cacheImpl[K, V] {
+	if len(entries) > capacity {
+		panic("lfu: more prewarmed entries than capacity")
+	}
+
+	cache := New[K, V](capacity)
+
+	for _, entry := range entries {
+		if _, ok := cache.index[entry.Key]; ok {
+			panic("lfu: duplicate prewarmed key")
+		}
+
+		cache.insertWithFrequency(entry.Key, entry.Value, entry.Frequency)
+	}
+
+	return cache
+}
@@ -0,0 +1,41 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrewarmedMatchesInput(t *testing.T) {
+	t.Parallel()
+
+	cache := NewPrewarmed(5, []Entry[string, int]{
+		{Key: "a", Value: 1, Frequency: 3},
+		{Key: "b", Value: 2, Frequency: 1},
+		{Key: "c", Value: 3, Frequency: 3},
+		{Key: "d", Value: 4, Frequency: 2},
+		{Key: "e", Value: 5, Frequency: 0},
+	})
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"c", "a", "d", "e", "b"}, keys)
+	require.Equal(t, []int{3, 1, 4, 5, 2}, values)
+	require.Equal(t, map[string]int{"a": 3, "b": 1, "c": 3, "d": 2, "e": 1}, cache.FrequenciesOf(keys))
+	require.NoError(t, cache.Verify())
+
+	cache.Put("f", 6)
+
+	_, err := cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestPrewarmedRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	require.Panics(t, func() {
+		NewPrewarmed(1, []Entry[string, int]{{Key: "a"}, {Key: "b"}})
+	})
+	require.Panics(t, func() {
+		NewPrewarmed(2, []Entry[string, int]{{Key: "a"}, {Key: "a"}})
+	})
+}
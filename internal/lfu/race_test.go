@@ -0,0 +1,6 @@
+//go:build race
+
+package lfu
+
+// raceEnabled reports whether the tests run with the race detector.
+const raceEnabled = true
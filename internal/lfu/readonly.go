@@ -0,0 +1,80 @@
+package lfu
+
+import "iter"
+
+// readOnlyCache represents a view of a cache that ignores writes
+type readOnlyCache[K comparable, V any] struct {
+	cache           Cache[K, V]
+	frozenFrequency bool
+}
+
+// readOnlyConfig collects ReadOnly options
+type readOnlyConfig struct {
+	frozenFrequency bool
+}
+
+// ReadOnlyOption configures the view created by ReadOnly.
+type ReadOnlyOption func(*readOnlyConfig)
+
+// WithFrozenFrequency makes Get of the read-only view leave frequencies untouched.
+// If the underlying cache has a Peek method (as caches created by New do), it is used in O(1).
+// Otherwise the value is looked up by walking All in O(size).
+func WithFrozenFrequency() ReadOnlyOption {
+	return func(c *readOnlyConfig) {
+		c.frozenFrequency = true
+	}
+}
+
+// ReadOnly returns a view of the cache for code that must not modify it.
+//
+// Put on the view is a no-op, every other method is forwarded to the cache.
+// By default Get still increments the frequency of the key as usual,
+// use WithFrozenFrequency to make reads side-effect free.
+func ReadOnly[K comparable, V any](cache Cache[K, V], options ...ReadOnlyOption) Cache[K, V] {
+	var config readOnlyConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	return &readOnlyCache[K, V]{
+		cache:           cache,
+		frozenFrequency: config.frozenFrequency,
+	}
+}
+
+func (c *readOnlyCache[K, V]) Get(key K) (V, error) {
+	if !c.frozenFrequency {
+		return c.cache.Get(key)
+	}
+
+	if peeker, ok := c.cache.(interface{ Peek(key K) (V, error) }); ok {
+		return peeker.Peek(key)
+	}
+
+	for k, v := range c.cache.All() {
+		if k == key {
+			return v, nil
+		}
+	}
+
+	var zero V
+	return zero, ErrKeyNotFound
+}
+
+func (c *readOnlyCache[K, V]) Put(K, V) {}
+
+func (c *readOnlyCache[K, V]) All() iter.Seq2[K, V] {
+	return c.cache.All()
+}
+
+func (c *readOnlyCache[K, V]) Size() int {
+	return c.cache.Size()
+}
+
+func (c *readOnlyCache[K, V]) Capacity() int {
+	return c.cache.Capacity()
+}
+
+func (c *readOnlyCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	return c.cache.GetKeyFrequency(key)
+}
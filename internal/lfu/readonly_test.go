@@ -0,0 +1,59 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyIgnoresPut(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+	cache.Put(1, 10)
+
+	view := ReadOnly[int, int](cache)
+	view.Put(1, 100)
+	view.Put(2, 20)
+
+	require.Equal(t, 1, cache.Size())
+
+	value, err := view.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+}
+
+func TestReadOnlyFrozenFrequency(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+	cache.Put(1, 10)
+
+	views := []Cache[int, int]{
+		ReadOnly[int, int](cache, WithFrozenFrequency()),
+		ReadOnly(Tiered[int, int](cache), WithFrozenFrequency()),
+	}
+
+	for _, view := range views {
+		for range 3 {
+			value, err := view.Get(1)
+			require.NoError(t, err)
+			require.Equal(t, 10, value)
+		}
+
+		_, err := view.Get(2)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		frequency, err := view.GetKeyFrequency(1)
+		require.NoError(t, err)
+		require.Equal(t, 1, frequency)
+	}
+
+	require.Equal(t, 1, cache.Size())
+	require.Equal(t, 2, views[0].Capacity())
+	require.Equal(t, Stats{}, cache.Stats())
+}
@@ -0,0 +1,64 @@
+package lfu
+
+import "lfucache/internal/linkedlist"
+
+// WithGlobalRecency enables tracking of the access order across all frequencies.
+// It costs an extra list node per entry and O(1) work on every access.
+func WithGlobalRecency[K comparable, V any]() Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		if l.recency != nil {
+			return
+		}
+
+		l.recency = linkedlist.New[K]()
+
+		for container := l.sequence.Head(); container != nil; container = container.Next() {
+			for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+				l.markRecent(node)
+			}
+		}
+	}
+}
+
+// RecentKeys returns up to n keys ordered from the most recently to the least recently
+// touched one, regardless of their frequencies. Both insertion and access count as a touch.
+//
+// Requires WithGlobalRecency, otherwise returns nil.
+//
+// O(n)
+func (l *cacheImpl[K, V]) RecentKeys(n int) []K {
+	if l.recency == nil || n <= 0 {
+		return nil
+	}
+
+	keys := make([]K, 0, min(n, l.recency.Len()))
+	for key := range l.recency.Backward() {
+		if len(keys) == n {
+			break
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// markRecent records an access of the entry: it moves the entry to the most recent position
+// of the global recency list and stamps the access time if WithAccessTimes is set.
+func (l *cacheImpl[K, V]) markRecent(node *linkedlist.Node[cacheData[K, V]]) {
+	if l.trackAccessTimes {
+		node.Value.accessedAt = l.now().UnixNano()
+	}
+
+	if l.recency == nil {
+		return
+	}
+
+	if node.Value.recency == nil {
+		node.Value.recency = l.recency.PushBack(node.Value.key)
+		return
+	}
+
+	l.recency.Remove(node.Value.recency)
+	l.recency.PushBackNode(node.Value.recency)
+}
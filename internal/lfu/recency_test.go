@@ -0,0 +1,38 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentKeysAcrossFrequencies(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(4, WithGlobalRecency[int, int]())
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	cache.Put(3, 30)
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(2)
+	cache.Put(4, 40)
+	_, _ = cache.Get(3)
+
+	require.Equal(t, []int{3, 4, 2, 1}, cache.RecentKeys(10))
+	require.Equal(t, []int{3, 4}, cache.RecentKeys(2))
+	require.Empty(t, cache.RecentKeys(0))
+
+	cache.Put(5, 50)
+	require.Equal(t, []int{5, 3, 2, 1}, cache.RecentKeys(4))
+}
+
+func TestRecentKeysRequiresOption(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+	cache.Put(1, 10)
+
+	require.Nil(t, cache.RecentKeys(1))
+}
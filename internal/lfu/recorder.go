@@ -0,0 +1,136 @@
+package lfu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ErrMalformedRecord = errors.New("malformed operation record")
+
+// WithRecorder writes a line to w for every Put, TryPut, Get and Remove call and
+// every capacity eviction, in the order they happen. Keys and values are formatted
+// with fmt's %v verb and quoted:
+//
+//	put "key" "value"
+//	get "key"
+//	remove "key"
+//	evict "key"
+//
+// The trace can be read back with ReadOps and applied to a fresh cache with Replay.
+// Other mutating methods (BumpMany, Rekey, PutWithTTL, ...) are not recorded,
+// so only traces of caches driven by Put, Get and Remove replay exactly.
+// Write errors are ignored. Without a recorder no formatting work is done.
+func WithRecorder[K comparable, V any](w io.Writer) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.recorder = w
+	}
+}
+
+// ReadOps parses a trace written by WithRecorder using the given key and value parsers.
+// Evict lines are validated and skipped, since evictions are the consequence of the other operations.
+// Errors are wrapped with the line number.
+func ReadOps[K comparable, V any](
+	r io.Reader,
+	parseKey func(string) (K, error),
+	parseValue func(string) (V, error),
+) ([]Op[K, V], error) {
+	ops := make([]Op[K, V], 0)
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		op, skip, err := parseOp(scanner.Text(), parseKey, parseValue)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if !skip {
+			ops = append(ops, op)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+func (l *cacheImpl[K, V]) record(kind string, key K) {
+	_, _ = fmt.Fprintf(l.recorder, "%s %s\n", kind, strconv.Quote(fmt.Sprint(key)))
+}
+
+func (l *cacheImpl[K, V]) recordPut(key K, value V) {
+	_, _ = fmt.Fprintf(l.recorder, "put %s %s\n", strconv.Quote(fmt.Sprint(key)), strconv.Quote(fmt.Sprint(value)))
+}
+
+// parseOp parses a single trace line. skip is true for evict lines.
+func parseOp[K comparable, V any](
+	line string,
+	parseKey func(string) (K, error),
+	parseValue func(string) (V, error),
+) (op Op[K, V], skip bool, err error) {
+	kind, rest, _ := strings.Cut(line, " ")
+
+	fields, err := unquoteFields(rest)
+	if err != nil {
+		return op, false, err
+	}
+
+	wantFields := 1
+
+	switch kind {
+	case "put":
+		op.Kind = OpPut
+		wantFields = 2
+	case "get":
+		op.Kind = OpGet
+	case "remove":
+		op.Kind = OpRemove
+	case "evict":
+		skip = true
+	default:
+		return op, false, fmt.Errorf("%w: unknown operation %q", ErrMalformedRecord, kind)
+	}
+
+	if len(fields) != wantFields {
+		return op, false, fmt.Errorf("%w: %s expects %d arguments, got %d", ErrMalformedRecord, kind, wantFields, len(fields))
+	}
+
+	if op.Key, err = parseKey(fields[0]); err != nil {
+		return op, false, err
+	}
+
+	if op.Kind == OpPut {
+		if op.Value, err = parseValue(fields[1]); err != nil {
+			return op, false, err
+		}
+	}
+
+	return op, skip, nil
+}
+
+// unquoteFields splits a space separated list of quoted strings.
+func unquoteFields(s string) ([]string, error) {
+	fields := make([]string, 0, 2)
+
+	for s != "" {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedRecord, err)
+		}
+
+		field, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedRecord, err)
+		}
+
+		fields = append(fields, field)
+		s = strings.TrimPrefix(s[len(quoted):], " ")
+	}
+
+	return fields, nil
+}
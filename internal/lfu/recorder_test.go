@@ -0,0 +1,87 @@
+package lfu
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderFormat(t *testing.T) {
+	t.Parallel()
+
+	var trace bytes.Buffer
+	cache := NewWithOptions(1, WithRecorder[string, string](&trace))
+
+	cache.Put("a b", "x")
+	_, _ = cache.Get("a b")
+	cache.Put("c", "y \"z\"")
+	_ = cache.Remove("c")
+
+	require.Equal(t, `put "a b" "x"
+get "a b"
+put "c" "y \"z\""
+evict "a b"
+remove "c"
+`, trace.String())
+}
+
+func TestRecordedSessionReplays(t *testing.T) {
+	t.Parallel()
+
+	var trace bytes.Buffer
+	random := rand.New(rand.NewPCG(3, 4))
+	live := NewWithOptions(5, WithRecorder[int, string](&trace))
+
+	for range 500 {
+		key := random.IntN(12)
+
+		switch random.IntN(10) {
+		case 0:
+			_ = live.Remove(key)
+		case 1, 2, 3, 4:
+			_, _ = live.Get(key)
+		default:
+			live.Put(key, strconv.Itoa(random.IntN(100)))
+		}
+	}
+
+	ops, err := ReadOps(&trace, strconv.Atoi, func(s string) (string, error) {
+		return s, nil
+	})
+	require.NoError(t, err)
+
+	replayed := New[int, string](5)
+	replayed.Replay(slices.Values(ops))
+
+	require.True(t, Equal(live, replayed))
+}
+
+func TestReadOpsMalformed(t *testing.T) {
+	t.Parallel()
+
+	parseValue := func(s string) (int, error) {
+		return strconv.Atoi(s)
+	}
+
+	_, err := ReadOps(strings.NewReader("put \"1\" \"2\"\nfrobnicate \"1\"\n"), strconv.Atoi, parseValue)
+	require.ErrorIs(t, err, ErrMalformedRecord)
+	require.ErrorContains(t, err, "line 2")
+
+	_, err = ReadOps(strings.NewReader("put \"1\"\n"), strconv.Atoi, parseValue)
+	require.ErrorIs(t, err, ErrMalformedRecord)
+
+	_, err = ReadOps(strings.NewReader("get 1\n"), strconv.Atoi, parseValue)
+	require.ErrorIs(t, err, ErrMalformedRecord)
+
+	_, err = ReadOps(strings.NewReader("get \"x\"\n"), strconv.Atoi, parseValue)
+	require.ErrorContains(t, err, "line 1")
+
+	ops, err := ReadOps(strings.NewReader("evict \"1\"\nget \"1\"\n"), strconv.Atoi, parseValue)
+	require.NoError(t, err)
+	require.Equal(t, []Op[int, int]{{Kind: OpGet, Key: 1}}, ops)
+}
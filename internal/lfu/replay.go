@@ -0,0 +1,81 @@
+package lfu
+
+import "iter"
+
+// OpKind is the kind of a recorded cache operation.
+type OpKind int
+
+const (
+	// OpPut stores Op.Value under Op.Key, see Cache.Put.
+	OpPut OpKind = iota + 1
+	// OpGet reads Op.Key, see Cache.Get.
+	OpGet
+	// OpRemove deletes Op.Key, see Remove.
+	OpRemove
+)
+
+// Op is a single operation applied to the cache.
+type Op[K comparable, V any] struct {
+	Kind  OpKind
+	Key   K
+	Value V
+}
+
+// Replay applies the operations to the cache in order, exactly as if the corresponding
+// methods were called. Replaying the same operations on caches created with the same
+// capacity and options produces identical caches, see Equal.
+// Operations of unknown kind are skipped.
+//
+// O(number of operations), not amortized
+func (l *cacheImpl[K, V]) Replay(ops iter.Seq[Op[K, V]]) {
+	for op := range ops {
+		switch op.Kind {
+		case OpPut:
+			l.Put(op.Key, op.Value)
+		case OpGet:
+			_, _ = l.Get(op.Key)
+		case OpRemove:
+			_ = l.Remove(op.Key)
+		}
+	}
+}
+
+// Equal reports whether the caches have the same capacity and hold the same entries
+// with the same values, frequencies and recency order.
+// Options, pins, expiration deadlines and stats are not compared.
+//
+// O(capacity)
+func Equal[K comparable, V comparable](a, b *cacheImpl[K, V]) bool {
+	if a.Capacity() != b.Capacity() || a.Size() != b.Size() {
+		return false
+	}
+
+	left, right := a.sequence.Head(), b.sequence.Head()
+
+	for left != nil && right != nil {
+		if left.Value.entries.Len() == 0 {
+			left = left.Next()
+			continue
+		}
+
+		if right.Value.entries.Len() == 0 {
+			right = right.Next()
+			continue
+		}
+
+		if left.Value.freq != right.Value.freq || left.Value.entries.Len() != right.Value.entries.Len() {
+			return false
+		}
+
+		l, r := left.Value.entries.Head(), right.Value.entries.Head()
+		for ; l != nil; l, r = l.Next(), r.Next() {
+			if l.Value.key != r.Value.key || l.Value.value != r.Value.value {
+				return false
+			}
+		}
+
+		left, right = left.Next(), right.Next()
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package lfu
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayReproducesCache(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewPCG(1, 2))
+	live := New[int, int](8)
+	ops := make([]Op[int, int], 0, 1000)
+
+	for range 1000 {
+		op := Op[int, int]{Key: random.IntN(20)}
+
+		switch random.IntN(10) {
+		case 0:
+			op.Kind = OpRemove
+			_ = live.Remove(op.Key)
+		case 1, 2, 3, 4:
+			op.Kind = OpGet
+			_, _ = live.Get(op.Key)
+		default:
+			op.Kind = OpPut
+			op.Value = random.IntN(100)
+			live.Put(op.Key, op.Value)
+		}
+
+		ops = append(ops, op)
+	}
+
+	replayed := New[int, int](8)
+	replayed.Replay(slices.Values(ops))
+
+	require.True(t, Equal(live, replayed))
+
+	liveKeys, liveValues := collect(live.All())
+	keys, values := collect(replayed.All())
+	require.Equal(t, liveKeys, keys)
+	require.Equal(t, liveValues, values)
+	require.Equal(t, live.FrequenciesOf(liveKeys), replayed.FrequenciesOf(keys))
+}
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	a := New[int, int](3)
+	b := New[int, int](3)
+	require.True(t, Equal(a, b))
+
+	a.Put(1, 10)
+	a.Put(2, 20)
+	b.Put(2, 20)
+	b.Put(1, 10)
+	require.False(t, Equal(a, b))
+
+	_, _ = a.Get(1)
+	_, _ = a.Get(2)
+	_, _ = b.Get(1)
+	_, _ = b.Get(2)
+	require.True(t, Equal(a, b))
+
+	b.Put(2, 21)
+	a.Put(2, 22)
+	require.False(t, Equal(a, b))
+
+	require.False(t, Equal(New[int, int](3), New[int, int](4)))
+}
@@ -0,0 +1,33 @@
+package lfu
+
+// Reserve inserts a placeholder for an absent key and returns true, so that among callers
+// missing the same key only the one that reserved it computes the value.
+// Returns false if the key is present, reserved or not, or if the cache is full
+// and nothing can be evicted, see PutChecked.
+//
+// Until the reservation is fulfilled by Put, Get, Peek and GetKeyFrequency treat the key as absent,
+// while the placeholder occupies space, is yielded by All and is evicted like any other entry
+// with the zero value. It is not a value, so ToMap, DumpText and Split skip it, while EncodeGob
+// keeps it a reservation. Fulfilling the reservation does not count as an access: the entry
+// keeps frequency 1.
+//
+// A reservation never expires, so if computing the value fails the reserver must Remove the key,
+// otherwise every other caller keeps seeing it reserved until it is evicted.
+//
+// O(1), not amortized, unless there are pinned entries or an eviction veto is set
+func (l *cacheImpl[K, V]) Reserve(key K) bool {
+	defer l.debugVerify()
+
+	if _, ok := l.index[key]; ok {
+		return false
+	}
+
+	var zero V
+	if l.PutChecked(key, zero) != nil {
+		return false
+	}
+
+	l.index[key].Value.reserved = true
+
+	return true
+}
@@ -0,0 +1,132 @@
+package lfu
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserve(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+
+	require.False(t, cache.Reserve("a"))
+	require.True(t, cache.Reserve("b"))
+	require.False(t, cache.Reserve("b"))
+
+	_, err := cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, 2, cache.Size())
+
+	cache.Put("b", 2)
+
+	value, err := cache.Get("b")
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+
+	frequency, err := cache.GetKeyFrequency("b")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+}
+
+func TestReserveConcurrent(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[string, int](4)
+
+	var (
+		wg   sync.WaitGroup
+		wins atomic.Int32
+	)
+
+	for range 32 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if cache.Reserve("key") {
+				wins.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, int32(1), wins.Load())
+	require.Equal(t, 1, cache.Size())
+}
+
+func TestReservationExports(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	require.True(t, cache.Reserve("r"))
+
+	require.Equal(t, map[string]int{"a": 1, "b": 2}, cache.ToMap())
+
+	var dump strings.Builder
+	require.NoError(t, cache.DumpText(&dump, func(key string, value int) string {
+		return key + "=" + strconv.Itoa(value)
+	}))
+	require.Equal(t, "b=2\na=1\n", dump.String())
+
+	hot, cold := cache.Split(0.5)
+	require.Equal(t, map[string]int{"b": 2}, hot.ToMap())
+	require.Equal(t, map[string]int{"a": 1}, cold.ToMap())
+
+	var buf bytes.Buffer
+	require.NoError(t, cache.EncodeGob(&buf))
+
+	decoded := New[string, int](4)
+	require.NoError(t, decoded.DecodeGob(&buf))
+	require.Equal(t, 3, decoded.Size())
+
+	_, err := decoded.Get("r")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, decoded.Reserve("r"))
+
+	decoded.Put("r", 5)
+
+	value, err := decoded.Get("r")
+	require.NoError(t, err)
+	require.Equal(t, 5, value)
+}
+
+func TestReservationIsAbsent(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	require.True(t, cache.Reserve("a"))
+
+	_, err := cache.Peek("a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = ReadOnly[string, int](cache, WithFrozenFrequency()).Get("a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = cache.GetKeyFrequency("a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Empty(t, cache.FrequenciesOf([]string{"a"}))
+}
+
+func TestReservationReleasedByRemove(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	require.True(t, cache.Reserve("a"))
+	require.False(t, cache.Reserve("a"))
+
+	// The reserver failed to compute the value and releases the key.
+	require.NoError(t, cache.Remove("a"))
+	require.True(t, cache.Reserve("a"))
+}
@@ -0,0 +1,69 @@
+package lfu
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// WithSampleRand sets the source of randomness used by SampleFrequencies,
+// so samples are reproducible with a seeded generator. By default the global source is used.
+// The generator is not safe for concurrent use: do not share it between caches
+// and use it only with a synchronized cache if the cache is shared.
+func WithSampleRand[K comparable, V any](random *rand.Rand) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.random = random
+	}
+}
+
+// SampleFrequencies returns the frequencies of n entries chosen uniformly at random without replacement,
+// in descending order, to approximate the frequency distribution of a large cache cheaply.
+// For n >= Size the frequencies of all entries are returned, for n <= 0 an empty slice.
+// Frequencies are not changed.
+//
+// O(n * log(n) + number of containers), expected
+func (l *cacheImpl[K, V]) SampleFrequencies(n int) []int {
+	size := l.Size()
+	n = max(0, min(n, size))
+
+	// Floyd's algorithm picks n distinct positions in the order of All.
+	chosen := make(map[int]struct{}, n)
+	for j := size - n; j < size; j++ {
+		position := l.intN(j + 1)
+		if _, ok := chosen[position]; ok {
+			position = j
+		}
+
+		chosen[position] = struct{}{}
+	}
+
+	positions := make([]int, 0, n)
+	for position := range chosen {
+		positions = append(positions, position)
+	}
+
+	slices.Sort(positions)
+
+	// Entries of a container share the frequency, so only containers have to be walked.
+	frequencies := make([]int, 0, n)
+	container := l.sequence.Tail()
+	start := 0
+
+	for _, position := range positions {
+		for position >= start+container.Value.entries.Len() {
+			start += container.Value.entries.Len()
+			container = container.Prev()
+		}
+
+		frequencies = append(frequencies, container.Value.freq)
+	}
+
+	return frequencies
+}
+
+func (l *cacheImpl[K, V]) intN(n int) int {
+	if l.random == nil {
+		return rand.IntN(n)
+	}
+
+	return l.random.IntN(n)
+}
@@ -0,0 +1,57 @@
+package lfu
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleFrequencies(t *testing.T) {
+	t.Parallel()
+
+	newCache := func() *cacheImpl[int, int] {
+		cache := NewWithOptions(20, WithSampleRand[int, int](rand.New(rand.NewPCG(5, 6))))
+
+		for i := range 20 {
+			cache.Put(i, i)
+
+			for range (i * 3) % 5 {
+				_, _ = cache.Get(i)
+			}
+		}
+
+		return cache
+	}
+
+	cache := newCache()
+
+	available := make(map[int]int)
+	for _, bucket := range cache.FrequencySpectrum() {
+		available[bucket.Frequency] = bucket.Count
+	}
+
+	sample := cache.SampleFrequencies(8)
+	require.Len(t, sample, 8)
+	require.True(t, slices.IsSortedFunc(sample, func(a, b int) int { return b - a }))
+
+	// Entries are sampled without replacement, so no frequency occurs more often than in the cache.
+	for _, frequency := range sample {
+		require.Positive(t, available[frequency], "frequency %d", frequency)
+		available[frequency]--
+	}
+
+	require.Equal(t, sample, newCache().SampleFrequencies(8))
+
+	all := make([]int, 0)
+	for _, entry := range cache.AllIndexed() {
+		all = append(all, entry.Frequency)
+	}
+
+	require.Equal(t, all, cache.SampleFrequencies(cache.Size()))
+	require.Equal(t, all, cache.SampleFrequencies(100))
+	require.Empty(t, cache.SampleFrequencies(0))
+	require.Empty(t, cache.SampleFrequencies(-1))
+	require.Empty(t, New[int, int](4).SampleFrequencies(3))
+}
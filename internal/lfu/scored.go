@@ -0,0 +1,89 @@
+package lfu
+
+import (
+	"cmp"
+	"slices"
+
+	"lfucache/internal/linkedlist"
+)
+
+// scoreWeights are the weights of WithScoredEviction.
+type scoreWeights struct {
+	frequency float64
+	recency   float64
+}
+
+// WithScoredEviction replaces the LFU eviction order with a tunable score:
+//
+//	score = freqWeight*frequency + recencyWeight*recencyRank
+//
+// where recencyRank is 0 for the least recently touched entry and Size()-1 for
+// the most recently touched one. The entry with the lowest score is evicted,
+// the least recently touched one among equal scores. Raising recencyWeight
+// shifts eviction towards older entries regardless of their frequency.
+// Pins and the eviction veto are respected; WithKeyTieBreak and WithSecondaryPolicy are ignored.
+//
+// The option enables WithGlobalRecency. Every eviction scans all entries,
+// so Put into a full cache becomes O(size).
+func WithScoredEviction[K comparable, V any](freqWeight, recencyWeight float64) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		WithGlobalRecency[K, V]()(l)
+		l.scoreWeights = &scoreWeights{frequency: freqWeight, recency: recencyWeight}
+	}
+}
+
+// scoredEntry is an evictable entry with its score.
+type scoredEntry[K comparable, V any] struct {
+	node  *linkedlist.Node[cacheData[K, V]]
+	score float64
+}
+
+// scoredEntries returns evictable entries with their scores from the least to the most recently touched.
+func (l *cacheImpl[K, V]) scoredEntries() []scoredEntry[K, V] {
+	entries := make([]scoredEntry[K, V], 0, l.Size())
+	rank := 0
+
+	for key := range l.recency.All() {
+		node := l.index[key]
+
+		if l.evictable(node) {
+			entries = append(entries, scoredEntry[K, V]{
+				node: node,
+				score: l.scoreWeights.frequency*float64(node.Value.container.Value.freq) +
+					l.scoreWeights.recency*float64(rank),
+			})
+		}
+
+		rank++
+	}
+
+	return entries
+}
+
+// scoredVictim returns the evictable entry with the lowest score or nil if there is none.
+func (l *cacheImpl[K, V]) scoredVictim() *linkedlist.Node[cacheData[K, V]] {
+	entries := l.scoredEntries()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return slices.MinFunc(entries, compareScores[K, V]).node
+}
+
+// scoredOrder returns evictable entries in eviction order: ascending score,
+// the least recently touched first among equal scores.
+func (l *cacheImpl[K, V]) scoredOrder() []*linkedlist.Node[cacheData[K, V]] {
+	entries := l.scoredEntries()
+	slices.SortStableFunc(entries, compareScores[K, V])
+
+	nodes := make([]*linkedlist.Node[cacheData[K, V]], 0, len(entries))
+	for _, entry := range entries {
+		nodes = append(nodes, entry.node)
+	}
+
+	return nodes
+}
+
+func compareScores[K comparable, V any](a, b scoredEntry[K, V]) int {
+	return cmp.Compare(a.score, b.score)
+}
@@ -0,0 +1,58 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoredEvictionRecencyWeight(t *testing.T) {
+	t.Parallel()
+
+	evictedWith := func(recencyWeight float64) string {
+		cache := NewWithOptions(3, WithScoredEviction[string, int](1, recencyWeight))
+
+		// "old" is hot but untouched for a while, "new" is cold but just inserted.
+		cache.Put("old", 1)
+
+		for range 4 {
+			_, _ = cache.Get("old")
+		}
+
+		cache.Put("mid", 2)
+		_, _ = cache.Get("mid")
+		cache.Put("new", 3)
+
+		coldest, _, ok := cache.Coldest()
+		require.True(t, ok)
+
+		cache.Put("next", 4)
+
+		_, err := cache.Peek(coldest)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+
+		return coldest
+	}
+
+	// Scores for frequencies 5, 2, 1 and recency ranks 0, 1, 2.
+	require.Equal(t, "new", evictedWith(0))
+	require.Equal(t, "mid", evictedWith(2))
+	require.Equal(t, "old", evictedWith(10))
+}
+
+func TestScoredEvictionRespectsPins(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithScoredEviction[string, int](0, 1))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	require.NoError(t, cache.Pin("a"))
+
+	require.Equal(t, []Entry[string, int]{{Key: "b", Value: 2, Frequency: 1}}, cache.PreviewEvictions(2))
+
+	cache.Put("c", 3)
+
+	keys, _ := collect(cache.All())
+	require.ElementsMatch(t, []string{"a", "c"}, keys)
+}
@@ -0,0 +1,43 @@
+package lfu
+
+import (
+	"cmp"
+
+	"lfucache/internal/linkedlist"
+)
+
+// SecondaryPolicy defines which of the entries with the lowest frequency is evicted.
+type SecondaryPolicy int
+
+const (
+	// SecondaryLRU evicts the least recently used entry. This is the default.
+	SecondaryLRU SecondaryPolicy = iota
+	// SecondaryFIFO evicts the entry inserted first, regardless of when it was last used.
+	SecondaryFIFO
+)
+
+// WithSecondaryPolicy sets how ties between entries with the lowest frequency are broken on eviction.
+// WithKeyTieBreak takes precedence over the policy.
+//
+// With SecondaryFIFO eviction becomes O(container size) since the whole lowest
+// frequency container is scanned.
+func WithSecondaryPolicy[K comparable, V any](policy SecondaryPolicy) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.secondaryPolicy = policy
+	}
+}
+
+// breaksTies reports whether entries of the same frequency are ordered for eviction
+// by compareTies rather than by recency.
+func (l *cacheImpl[K, V]) breaksTies() bool {
+	return l.keyTieBreak != nil || l.secondaryPolicy == SecondaryFIFO
+}
+
+// compareTies orders entries of the same frequency for eviction, the first one is evicted first.
+func (l *cacheImpl[K, V]) compareTies(a, b *linkedlist.Node[cacheData[K, V]]) int {
+	if l.keyTieBreak != nil {
+		return l.keyTieBreak(a.Value.key, b.Value.key)
+	}
+
+	return cmp.Compare(a.Value.insertedAt, b.Value.insertedAt)
+}
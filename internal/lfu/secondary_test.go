@@ -0,0 +1,63 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecondaryPolicyWithEqualFrequencies(t *testing.T) {
+	t.Parallel()
+
+	survivors := func(policy SecondaryPolicy) []string {
+		cache := NewWithOptions(3,
+			WithMaxFrequency[string, int](1),
+			WithSecondaryPolicy[string, int](policy),
+		)
+
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		cache.Put("c", 3)
+		_, _ = cache.Get("a")
+		_, _ = cache.Get("b")
+
+		cache.Put("d", 4)
+
+		for key := range cache.All() {
+			frequency, err := cache.GetKeyFrequency(key)
+			require.NoError(t, err)
+			require.Equal(t, 1, frequency)
+		}
+
+		keys, _ := collect(cache.All())
+
+		return keys
+	}
+
+	require.Equal(t, []string{"d", "b", "a"}, survivors(SecondaryLRU))
+	require.Equal(t, []string{"d", "b", "c"}, survivors(SecondaryFIFO))
+}
+
+func TestSecondaryFIFOAfterPromotion(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(3, WithSecondaryPolicy[string, int](SecondaryFIFO))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("c")
+	_, _ = cache.Get("b")
+	_, _ = cache.Get("a")
+
+	require.Equal(t, []Entry[string, int]{
+		{Key: "a", Value: 1, Frequency: 2},
+		{Key: "b", Value: 2, Frequency: 2},
+		{Key: "c", Value: 3, Frequency: 2},
+	}, cache.PreviewEvictions(3))
+
+	cache.Put("d", 4)
+
+	_, err := cache.Get("a")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
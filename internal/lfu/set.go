@@ -0,0 +1,53 @@
+package lfu
+
+import "iter"
+
+// LFUSet represents a set of keys with LFU eviction. Create it with NewSet.
+type LFUSet[K comparable] struct { //nolint:revive // The requested name, although lfu.LFUSet stutters.
+	cache *cacheImpl[K, struct{}]
+}
+
+// NewSet initializes the set with the given capacity. Adding a member beyond the capacity
+// evicts the least frequently used one, where both Add and Contains of a member count as its use.
+// Capacity semantics are the same as in New.
+func NewSet[K comparable](capacity int) *LFUSet[K] {
+	return &LFUSet[K]{cache: New[K, struct{}](capacity)}
+}
+
+// Add inserts the key or increments its frequency if it is already a member.
+//
+// O(1), not amortized
+func (s *LFUSet[K]) Add(key K) {
+	s.cache.Put(key, struct{}{})
+}
+
+// Contains reports whether the key is a member, incrementing its frequency if it is.
+//
+// O(1), not amortized
+func (s *LFUSet[K]) Contains(key K) bool {
+	_, err := s.cache.Get(key)
+	return err == nil
+}
+
+// Remove deletes the key from the set if it is a member.
+//
+// O(1), not amortized
+func (s *LFUSet[K]) Remove(key K) {
+	_ = s.cache.Remove(key)
+}
+
+// Size returns the number of members.
+func (s *LFUSet[K]) Size() int {
+	return s.cache.Size()
+}
+
+// All returns the iterator over members in descending order of frequency, see Cache.All.
+func (s *LFUSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range s.cache.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
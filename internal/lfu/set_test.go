@@ -0,0 +1,32 @@
+package lfu
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEvictsLeastFrequentlyAdded(t *testing.T) {
+	t.Parallel()
+
+	set := NewSet[string](3)
+
+	set.Add("a")
+	set.Add("b")
+	set.Add("c")
+	set.Add("a")
+	require.True(t, set.Contains("c"))
+
+	set.Add("d")
+
+	require.False(t, set.Contains("b"))
+	require.Equal(t, []string{"c", "a", "d"}, slices.Collect(set.All()))
+	require.Equal(t, 3, set.Size())
+
+	set.Remove("a")
+	set.Remove("x")
+
+	require.False(t, set.Contains("a"))
+	require.Equal(t, 2, set.Size())
+}
@@ -0,0 +1,56 @@
+package lfu
+
+import "math"
+
+// Split copies the entries into two new caches: hot receives the ceil(fraction * n)
+// of the n entries holding a value that come first in All order, cold receives the rest;
+// markers stored by PutNegative and reservations stored by Reserve are not copied. Both keep the frequencies
+// and the recency order of their entries. The capacity of hot equals its size and cold
+// gets the remaining capacity, so the capacities add up to the capacity of the cache,
+// unless the cache is over its soft capacity (see WithSoftCapacity): then cold gets
+// a capacity equal to its size so that no entry is lost.
+//
+// The cache itself is left unchanged. Options, pins, expiration deadlines and stats are not copied.
+//
+// Panics if fraction is not within [0, 1].
+//
+// O(size)
+func (l *cacheImpl[K, V]) Split(fraction float64) (hot, cold *cacheImpl[K, V]) {
+	if !(fraction >= 0 && fraction <= 1) {
+		panic("lfu: split fraction must be within [0, 1]")
+	}
+
+	size := 0
+	for node := l.first(); node != nil; node = l.successor(node) {
+		if !node.Value.placeholder() {
+			size++
+		}
+	}
+
+	hotSize := int(math.Ceil(fraction * float64(size)))
+	coldSize := size - hotSize
+
+	hot = New[K, V](hotSize)
+	cold = New[K, V](max(coldSize, l.Capacity()-hotSize))
+
+	// Entries are copied in eviction order, so each one becomes the most recently used of its frequency.
+	copied := 0
+
+	for container := l.sequence.Head(); container != nil; container = container.Next() {
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			if node.Value.placeholder() {
+				continue
+			}
+
+			target := cold
+			if copied >= coldSize {
+				target = hot
+			}
+
+			target.insertWithFrequency(node.Value.key, node.Value.value, container.Value.freq)
+			copied++
+		}
+	}
+
+	return hot, cold
+}
@@ -0,0 +1,99 @@
+package lfu
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](10)
+
+	for i := range 8 {
+		cache.Put(i, i*10)
+
+		for range i % 4 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	keys, values := collect(cache.All())
+
+	hot, cold := cache.Split(0.5)
+
+	hotKeys, hotValues := collect(hot.All())
+	coldKeys, coldValues := collect(cold.All())
+
+	require.Equal(t, keys[:4], hotKeys)
+	require.Equal(t, values[:4], hotValues)
+	require.Equal(t, keys[4:], coldKeys)
+	require.Equal(t, values[4:], coldValues)
+
+	require.Equal(t, cache.FrequenciesOf(hotKeys), hot.FrequenciesOf(hotKeys))
+	require.Equal(t, cache.FrequenciesOf(coldKeys), cold.FrequenciesOf(coldKeys))
+
+	for _, key := range hotKeys {
+		frequency, err := hot.GetKeyFrequency(key)
+		require.NoError(t, err)
+
+		for _, coldKey := range coldKeys {
+			coldFrequency, err := cold.GetKeyFrequency(coldKey)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, frequency, coldFrequency)
+		}
+	}
+
+	require.Equal(t, 4, hot.Capacity())
+	require.Equal(t, 6, cold.Capacity())
+	require.Equal(t, 8, cache.Size())
+	require.NoError(t, hot.Verify())
+	require.NoError(t, cold.Verify())
+}
+
+func TestSplitBounds(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](3)
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+
+	hot, cold := cache.Split(0)
+	require.Zero(t, hot.Size())
+	require.Equal(t, 2, cold.Size())
+
+	hot, cold = cache.Split(1)
+	require.Equal(t, 2, hot.Size())
+	require.Zero(t, cold.Size())
+	require.Equal(t, 1, cold.Capacity())
+
+	require.Panics(t, func() { cache.Split(1.1) })
+}
+
+func TestSplitOverSoftCapacity(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithSoftCapacity[int, int](4))
+	for i := range 4 {
+		cache.Put(i, i)
+	}
+
+	require.Equal(t, 4, cache.Size())
+
+	hot, cold := cache.Split(1)
+	require.Equal(t, 4, hot.Size())
+	require.Equal(t, 4, hot.Capacity())
+	require.Zero(t, cold.Size())
+	require.Zero(t, cold.Capacity())
+
+	hot, cold = cache.Split(0.25)
+	require.Equal(t, 1, hot.Size())
+	require.Equal(t, 3, cold.Size())
+	require.Equal(t, 3, cold.Capacity())
+
+	combined := hot.ToMap()
+	maps.Copy(combined, cold.ToMap())
+	require.Equal(t, cache.ToMap(), combined)
+}
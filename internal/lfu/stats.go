@@ -0,0 +1,40 @@
+package lfu
+
+// Stats holds the cache access counters accumulated since creation.
+type Stats struct {
+	// Hits is the number of Get calls that found the key.
+	Hits uint64
+	// Misses is the number of Get calls that returned ErrKeyNotFound.
+	Misses uint64
+	// Evictions is the number of entries removed to make room for new ones.
+	Evictions uint64
+}
+
+// Stats returns the current access counters.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Stats() Stats {
+	return l.stats
+}
+
+// TotalAccesses returns the number of accesses to present keys since creation: every
+// Get hit (including GetRefreshing and LoadOrStore hits), Put of a present key, bump by BumpMany
+// and repeated key in WarmFromAccessLog, and Increment of a present key. Each access counts once,
+// even when the frequency is capped or incremented by more than one (see WithPutFrequencyDelta).
+// Insertions, misses, Peek, writes skipped by WithDedupeWrites or WithPutFrequencyDelta(0)
+// and frequency manipulation such as SetFrequency or Rerank do not count.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) TotalAccesses() uint64 {
+	return l.accesses
+}
+
+// utilization returns the ratio of size to capacity.
+// A cache with zero capacity is considered fully utilized.
+func (l *cacheImpl[K, V]) utilization() float64 {
+	if l.Capacity() == 0 {
+		return 1
+	}
+
+	return float64(l.Size()) / float64(l.Capacity())
+}
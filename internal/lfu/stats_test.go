@@ -0,0 +1,42 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	cache := New[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	_, _ = cache.Get(1)
+	_, _ = cache.Get(3)
+	cache.Put(3, 30)
+
+	require.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1}, cache.Stats())
+}
+
+func TestTotalAccesses(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithMaxFrequency[string, int](2))
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	require.Zero(t, cache.TotalAccesses())
+
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("a")
+	_, _ = cache.Get("missing")
+	cache.Put("b", 20)
+	cache.BumpMany([]string{"a", "b", "missing"})
+	_, _ = cache.Peek("a")
+	require.NoError(t, cache.SetFrequency("b", 1))
+
+	require.Equal(t, uint64(5), cache.TotalAccesses())
+	require.Equal(t, uint64(2), cache.Stats().Hits)
+}
@@ -0,0 +1,257 @@
+package lfu
+
+import (
+	"iter"
+	"sync"
+
+	"lfucache/internal/linkedlist"
+)
+
+// synchronizedCache represents LFU cache safe for concurrent use
+type synchronizedCache[K comparable, V any] struct {
+	*lockedCache[K, V]
+
+	// maintenance is nil unless the maintenance goroutine was started, see WithMaintenanceInterval.
+	maintenance *maintenanceLoop
+}
+
+// lockedCache is the state shared by the synchronized cache and its maintenance goroutine,
+// which must not reference the synchronized cache itself, see startMaintenance.
+type lockedCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache *cacheImpl[K, V]
+}
+
+// NewSynchronized initializes the cache safe for concurrent use with the given capacity and options.
+// Every method holds a single mutex for its whole duration, so each call is atomic.
+// Callbacks set by options run under the mutex and must not call back into the cache.
+func NewSynchronized[K comparable, V any](capacity int, options ...Option[K, V]) *synchronizedCache[K, V] {
+	c := &synchronizedCache[K, V]{
+		lockedCache: &lockedCache[K, V]{cache: newWithOptions(capacity, options...)},
+	}
+	c.startMaintenance()
+
+	return c
+}
+
+func (c *synchronizedCache[K, V]) Get(key K) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.Get(key)
+}
+
+func (c *synchronizedCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Put(key, value)
+}
+
+// All iterates over a copy of the entries taken under the mutex, see AllSnapshot,
+// so the cache may be used during the iteration.
+func (c *synchronizedCache[K, V]) All() iter.Seq2[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.AllSnapshot()
+}
+
+// PublishExpvar registers an expvar.Var exposing the cache stats as JSON, see cacheImpl.PublishExpvar.
+// The stats are read under the mutex every time the variable is rendered.
+func (c *synchronizedCache[K, V]) PublishExpvar(name string) error {
+	return publishExpvar(name, func() expvarStats {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		return c.cache.expvarStats()
+	})
+}
+
+// ToMap returns a fresh map of the entries copied under the mutex, see cacheImpl.ToMap.
+func (c *synchronizedCache[K, V]) ToMap() map[K]V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.ToMap()
+}
+
+// AllChunked iterates over the entries in the same order as All, copying up to chunkSize
+// of them at a time under the mutex and yielding each chunk with the mutex released.
+// Unlike All it neither copies the whole cache at once nor blocks other users for the whole iteration.
+//
+// Mutations between chunks are not isolated: an entry whose frequency changes may be
+// skipped or yielded twice. The iteration resumes after the last yielded key;
+// if that key is gone, it resumes at the same position counted from the start.
+// A non-positive chunkSize is treated as 1.
+func (c *synchronizedCache[K, V]) AllChunked(chunkSize int) iter.Seq2[K, V] {
+	chunkSize = max(chunkSize, 1)
+
+	return func(yield func(K, V) bool) {
+		keys := make([]K, 0, chunkSize)
+		values := make([]V, 0, chunkSize)
+		position := 0
+
+		for {
+			keys, values = c.copyChunk(keys, values, position)
+
+			for i, key := range keys {
+				if !yield(key, values[i]) {
+					return
+				}
+			}
+
+			if len(keys) < chunkSize {
+				return
+			}
+
+			position += len(keys)
+		}
+	}
+}
+
+// copyChunk replaces the previous chunk in keys and values with up to cap(keys) entries
+// following its last key, or starting at position if there is no previous chunk or its last key is gone.
+func (c *synchronizedCache[K, V]) copyChunk(keys []K, values []V, position int) ([]K, []V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		node    *linkedlist.Node[cacheData[K, V]]
+		resumed bool
+	)
+
+	if len(keys) > 0 {
+		if last, ok := c.cache.index[keys[len(keys)-1]]; ok {
+			node, resumed = c.cache.successor(last), true
+		}
+	}
+
+	if !resumed {
+		node = c.cache.first()
+		for i := 0; i < position && node != nil; i++ {
+			node = c.cache.successor(node)
+		}
+	}
+
+	keys, values = keys[:0], values[:0]
+
+	for ; node != nil && len(keys) < cap(keys); node = c.cache.successor(node) {
+		keys = append(keys, node.Value.key)
+		values = append(values, node.Value.value)
+	}
+
+	return keys, values
+}
+
+// EvictionOrderStream copies the keys in eviction order under the mutex and sends them
+// to the returned unbuffered channel from a goroutine, closing it after the last one,
+// see cacheImpl.EvictionOrderStream. The cache may be used while the channel is drained,
+// but the channel still must be drained to release the goroutine.
+func (c *synchronizedCache[K, V]) EvictionOrderStream() <-chan K {
+	c.mu.Lock()
+
+	snapshot := make([]K, 0, c.cache.Size())
+	for node := range c.cache.evictionOrder() {
+		snapshot = append(snapshot, node.Value.key)
+	}
+
+	c.mu.Unlock()
+
+	keys := make(chan K)
+
+	go func() {
+		defer close(keys)
+
+		for _, key := range snapshot {
+			keys <- key
+		}
+	}()
+
+	return keys
+}
+
+// Reserve reserves the key atomically, see cacheImpl.Reserve:
+// of many concurrent calls for an absent key exactly one returns true.
+func (c *synchronizedCache[K, V]) Reserve(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.Reserve(key)
+}
+
+func (c *synchronizedCache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.Size()
+}
+
+func (c *synchronizedCache[K, V]) Capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.Capacity()
+}
+
+func (c *synchronizedCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.GetKeyFrequency(key)
+}
+
+// SetCapacity changes the capacity of the cache, see cacheImpl.SetCapacity.
+func (c *synchronizedCache[K, V]) SetCapacity(capacity int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.SetCapacity(capacity)
+}
+
+// Stat returns the size and the capacity observed at the same moment.
+// Calling Size and Capacity separately may observe a concurrent SetCapacity in between.
+func (c *synchronizedCache[K, V]) Stat() (size, capacity int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cache.Stat()
+}
+
+// WithLock calls fn with the underlying unsynchronized cache while holding the mutex,
+// so several operations performed by fn are observed by other goroutines as one atomic step.
+//
+// fn must only use the view it is given, which must not be used after fn returns.
+// Calling methods of the synchronized cache itself from fn deadlocks, since the mutex is not reentrant.
+// Keep fn short, every other user of the cache is blocked until it returns.
+func (c *synchronizedCache[K, V]) WithLock(fn func(cache Cache[K, V])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fn(c.cache)
+}
+
+// Modify atomically replaces the value of the key with the result of f, see cacheImpl.Modify.
+// The mutex is held across reading the value, calling f and writing the result,
+// so concurrent Modify calls of the same key never lose updates.
+//
+// f must only compute the new value from the arguments it is given. A re-entrant call,
+// i.e. calling any method of the cache from f, deadlocks and is not detected: the mutex
+// cannot tell the goroutine running f apart from other goroutines waiting for it.
+// A panic in f releases the mutex and leaves the entry unchanged.
+func (c *synchronizedCache[K, V]) Modify(key K, f func(value V, ok bool) V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Modify(key, f)
+}
+
+// ReplaceGroup atomically replaces the group of entries selected by selector with newEntries,
+// see cacheImpl.ReplaceGroup: other goroutines observe either the old group or the new one.
+// selector runs under the mutex and must not call methods of the cache.
+func (c *synchronizedCache[K, V]) ReplaceGroup(selector func(key K) bool, newEntries []Entry[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.ReplaceGroup(selector, newEntries)
+}
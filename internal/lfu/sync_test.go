@@ -0,0 +1,326 @@
+package lfu
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynchronizedImplementsCache(t *testing.T) {
+	t.Parallel()
+
+	var cache Cache[int, int] = NewSynchronized[int, int](2)
+
+	cache.Put(1, 10)
+	cache.Put(2, 20)
+	_, _ = cache.Get(1)
+	cache.Put(3, 30)
+
+	for key := range cache.All() {
+		_, err := cache.Get(key)
+		require.NoError(t, err)
+	}
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []int{1, 3}, keys)
+	require.Equal(t, 2, cache.Size())
+	require.Equal(t, 2, cache.Capacity())
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+}
+
+func TestSynchronizedStatConsistent(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[int, int](50)
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				cache.Put(worker*1_000_000+i, i)
+
+				if i%10 == 0 {
+					cache.SetCapacity(1 + (i*7+worker)%100)
+				}
+			}
+		}()
+	}
+
+	for range 10_000 {
+		size, capacity := cache.Stat()
+		require.LessOrEqual(t, size, capacity)
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestSynchronizedWithLockIsAtomic(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[string, int](2)
+	cache.Put("a", 50)
+	cache.Put("b", 50)
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			cache.WithLock(func(view Cache[string, int]) {
+				a, _ := view.Get("a")
+				b, _ := view.Get("b")
+
+				if a > 0 {
+					view.Put("a", a-1)
+					view.Put("b", b+1)
+				} else {
+					view.Put("a", b)
+					view.Put("b", 0)
+				}
+			})
+		}
+	}()
+
+	for range 10_000 {
+		total := 0
+		for _, value := range cache.All() {
+			total += value
+		}
+
+		require.Equal(t, 100, total)
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestSynchronizedAllChunked(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[int, int](10)
+
+	for i := range 10 {
+		cache.Put(i, i*10)
+
+		for range i % 3 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	expectedKeys, expectedValues := collect(cache.All())
+
+	for _, chunkSize := range []int{-1, 1, 3, 5, 10, 20} {
+		keys, values := collect(cache.AllChunked(chunkSize))
+		require.Equal(t, expectedKeys, keys)
+		require.Equal(t, expectedValues, values)
+	}
+
+	visited := 0
+	for key := range cache.AllChunked(4) {
+		// The mutex is released while a chunk is yielded, so the cache stays usable.
+		cache.Put(100+key, key)
+
+		visited++
+		if visited == 6 {
+			break
+		}
+	}
+
+	require.Equal(t, 6, visited)
+	require.Equal(t, 10, cache.Size())
+}
+
+func TestSynchronizedAllChunkedResumesAfterRemovedKey(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[int, int](6)
+	for i := range 6 {
+		cache.Put(i, i)
+	}
+
+	keys := make([]int, 0)
+	for key := range cache.AllChunked(2) {
+		keys = append(keys, key)
+
+		if key == 4 {
+			cache.WithLock(func(view Cache[int, int]) {
+				_ = view.(*cacheImpl[int, int]).Remove(4)
+			})
+		}
+	}
+
+	// Removing 4 shifts the remaining entries, so resuming by position skips 3.
+	require.Equal(t, []int{5, 4, 2, 1, 0}, keys)
+}
+
+func TestSynchronizedModifySerializes(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[string, int](2)
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 1000 {
+				cache.Modify("counter", func(value int, _ bool) int {
+					return value + 1
+				})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	value, err := cache.Get("counter")
+	require.NoError(t, err)
+	require.Equal(t, 8000, value)
+}
+
+func TestSynchronizedModifyAbsentKey(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[string, int](2)
+
+	cache.Modify("a", func(value int, ok bool) int {
+		require.False(t, ok)
+		require.Zero(t, value)
+
+		return 10
+	})
+
+	cache.Modify("a", func(value int, ok bool) int {
+		require.True(t, ok)
+		require.Equal(t, 10, value)
+
+		return 20
+	})
+
+	value, err := cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 20, value)
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 3, frequency)
+}
+
+func TestSynchronizedModifyPanicReleasesMutex(t *testing.T) {
+	t.Parallel()
+
+	cache := NewSynchronized[string, int](2)
+	cache.Put("a", 1)
+
+	require.Panics(t, func() {
+		cache.Modify("a", func(int, bool) int {
+			panic("modify failed")
+		})
+	})
+
+	value, err := cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	cache.Put("b", 2)
+	require.Equal(t, 2, cache.Size())
+}
+
+func TestSynchronizedReplaceGroupIsAtomic(t *testing.T) {
+	t.Parallel()
+
+	const groupSize = 5
+
+	group := func(generation int) []Entry[string, int] {
+		entries := make([]Entry[string, int], 0, groupSize)
+		for i := range groupSize {
+			entries = append(entries, Entry[string, int]{Key: "group:" + strconv.Itoa(i), Value: generation})
+		}
+
+		return entries
+	}
+
+	cache := NewSynchronized[string, int](20)
+	cache.Put("other", -1)
+	cache.ReplaceGroup(func(string) bool { return false }, group(0))
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for generation := 1; ; generation++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			cache.ReplaceGroup(func(key string) bool {
+				return strings.HasPrefix(key, "group:")
+			}, group(generation))
+		}
+	}()
+
+	for range 10_000 {
+		generations := make(map[int]int)
+
+		for key, value := range cache.All() {
+			if strings.HasPrefix(key, "group:") {
+				generations[value]++
+			}
+		}
+
+		require.Len(t, generations, 1)
+
+		for _, count := range generations {
+			require.Equal(t, groupSize, count)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+
+	require.Equal(t, groupSize+1, cache.Size())
+}
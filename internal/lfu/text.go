@@ -0,0 +1,55 @@
+package lfu
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// LoadText reads r line by line, parses every non-empty line with parse and stores
+// the result by Put, so eviction applies and later lines win over earlier ones.
+//
+// Loading stops at the first line parse fails on, returning its error wrapped with
+// the line number; the entries of the preceding lines stay in the cache.
+//
+// O(number of lines)
+func (l *cacheImpl[K, V]) LoadText(r io.Reader, parse func(line string) (K, V, error)) error {
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		if scanner.Text() == "" {
+			continue
+		}
+
+		key, value, err := parse(scanner.Text())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		l.Put(key, value)
+	}
+
+	return scanner.Err()
+}
+
+// DumpText writes every entry to w as a line formatted by format, in the same order as All.
+// Markers stored by PutNegative and reservations stored by Reserve hold no value and are skipped.
+// format must not return line breaks. The first write error is returned immediately.
+//
+// Frequencies and recency are not preserved unless format includes them:
+// LoadText of a dump restores the entries, each with frequency 1.
+//
+// O(size)
+func (l *cacheImpl[K, V]) DumpText(w io.Writer, format func(key K, value V) string) error {
+	for node := l.first(); node != nil; node = l.successor(node) {
+		if node.Value.placeholder() {
+			continue
+		}
+
+		if _, err := io.WriteString(w, format(node.Value.key, node.Value.value)+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package lfu
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errNoSeparator = errors.New("missing '='")
+
+func parseKeyValue(line string) (string, int, error) {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", 0, errNoSeparator
+	}
+
+	number, err := strconv.Atoi(value)
+
+	return key, number, err
+}
+
+func TestLoadText(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	err := cache.LoadText(strings.NewReader("a=1\nb=2\n\nc=3\nd=4\nc=30\n"), parseKeyValue)
+	require.NoError(t, err)
+
+	keys, values := collect(cache.All())
+	require.Equal(t, []string{"c", "d", "b"}, keys)
+	require.Equal(t, []int{30, 4, 2}, values)
+}
+
+func TestLoadTextMalformedLine(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+
+	err := cache.LoadText(strings.NewReader("a=1\nb\nc=3\n"), parseKeyValue)
+	require.ErrorIs(t, err, errNoSeparator)
+	require.ErrorContains(t, err, "line 2")
+
+	keys, _ := collect(cache.All())
+	require.Equal(t, []string{"a"}, keys)
+
+	err = cache.LoadText(strings.NewReader("x=1\ny=two\n"), parseKeyValue)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.ErrorContains(t, err, "line 2")
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("disk full")
+}
+
+func TestDumpTextRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	_, _ = cache.Get("b")
+
+	format := func(key string, value int) string {
+		return key + "=" + strconv.Itoa(value)
+	}
+
+	var buf strings.Builder
+	require.NoError(t, cache.DumpText(&buf, format))
+	require.Equal(t, "b=2\nc=3\na=1\n", buf.String())
+
+	restored := New[string, int](3)
+	require.NoError(t, restored.LoadText(strings.NewReader(buf.String()), parseKeyValue))
+	require.Equal(t, cache.ToMap(), restored.ToMap())
+
+	writer := &failingWriter{}
+	require.Error(t, cache.DumpText(writer, format))
+	require.Equal(t, 1, writer.writes)
+}
@@ -0,0 +1,77 @@
+package lfu
+
+import (
+	"errors"
+	"iter"
+)
+
+// tieredCache represents a chain of caches queried from the first (fastest) to the last one
+type tieredCache[K comparable, V any] struct {
+	tiers []Cache[K, V]
+}
+
+// Tiered combines the caches into a multi-level cache.
+//
+// Get queries the tiers in order and, on a hit in a lower tier, copies the value
+// into every tier above it. A key cached as absent by a tier (ErrNegativeCached) is
+// reported as such without querying the lower tiers. Put is write-through to the first tier only:
+// lower tiers are filled by their owners or by promotion chains of their own.
+// GetKeyFrequency reports the frequency from the first tier holding the key.
+// All, Size and Capacity describe the first tier.
+//
+// Panics if no caches are given.
+func Tiered[K comparable, V any](caches ...Cache[K, V]) Cache[K, V] {
+	if len(caches) == 0 {
+		panic("lfu: at least one tier is required")
+	}
+
+	return &tieredCache[K, V]{tiers: caches}
+}
+
+func (c *tieredCache[K, V]) Get(key K) (V, error) {
+	for i, tier := range c.tiers {
+		value, err := tier.Get(key)
+		if errors.Is(err, ErrNegativeCached) {
+			return value, err
+		}
+
+		if err != nil {
+			continue
+		}
+
+		for _, upper := range c.tiers[:i] {
+			upper.Put(key, value)
+		}
+
+		return value, nil
+	}
+
+	var zero V
+	return zero, ErrKeyNotFound
+}
+
+func (c *tieredCache[K, V]) Put(key K, value V) {
+	c.tiers[0].Put(key, value)
+}
+
+func (c *tieredCache[K, V]) All() iter.Seq2[K, V] {
+	return c.tiers[0].All()
+}
+
+func (c *tieredCache[K, V]) Size() int {
+	return c.tiers[0].Size()
+}
+
+func (c *tieredCache[K, V]) Capacity() int {
+	return c.tiers[0].Capacity()
+}
+
+func (c *tieredCache[K, V]) GetKeyFrequency(key K) (int, error) {
+	for _, tier := range c.tiers {
+		if frequency, err := tier.GetKeyFrequency(key); err == nil {
+			return frequency, nil
+		}
+	}
+
+	return 0, ErrKeyNotFound
+}
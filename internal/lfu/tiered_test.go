@@ -0,0 +1,72 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredPromotesHits(t *testing.T) {
+	t.Parallel()
+
+	l1 := New[int, int](2)
+	l2 := New[int, int](10)
+	cache := Tiered[int, int](l1, l2)
+
+	l2.Put(1, 10)
+	l2.Put(2, 20)
+
+	_, err := l1.GetKeyFrequency(1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	value, err := cache.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	value, err = l1.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	cache.Put(3, 30)
+	require.Equal(t, 2, cache.Size())
+	require.Equal(t, 2, cache.Capacity())
+
+	_, err = l2.GetKeyFrequency(3)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = cache.Get(42)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	frequency, err := cache.GetKeyFrequency(2)
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+
+	keys, _ := collect(cache.All())
+	require.ElementsMatch(t, []int{1, 3}, keys)
+}
+
+func TestTieredRequiresTier(t *testing.T) {
+	t.Parallel()
+
+	require.Panics(t, func() {
+		Tiered[int, int]()
+	})
+}
+
+func TestTieredNegativeCached(t *testing.T) {
+	t.Parallel()
+
+	l1 := New[string, int](2)
+	l2 := New[string, int](2)
+	cache := Tiered[string, int](l1, l2)
+
+	l1.PutNegative("a", 0)
+	l2.Put("a", 10)
+
+	_, err := cache.Get("a")
+	require.ErrorIs(t, err, ErrNegativeCached)
+
+	frequency, err := l2.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+}
@@ -0,0 +1,119 @@
+package lfu
+
+import (
+	"time"
+
+	"lfucache/internal/linkedlist"
+)
+
+// PutWithTTL behaves like Put but the entry expires after ttl passes according to the cache clock.
+// A non-positive ttl stores the entry without expiration, the same way Put does.
+//
+// Expired entries are removed lazily: Get treats them as absent and removes them,
+// until then they still occupy space, are counted by Size, yielded by All
+// and evicted by the usual LFU rules.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	if !l.TryPut(key, value) || ttl <= 0 {
+		return
+	}
+
+	l.index[key].Value.expiresAt = l.now().Add(ttl).UnixNano()
+}
+
+// GetRefreshing behaves like Get and additionally resets the expiration deadline
+// of the entry to now + ttl, implementing sliding expiration.
+// A non-positive ttl removes the deadline.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) GetRefreshing(key K, ttl time.Duration) (V, error) {
+	value, err := l.Get(key)
+	if err != nil {
+		return value, err
+	}
+
+	node := l.index[key]
+	if ttl <= 0 {
+		node.Value.expiresAt = 0
+	} else {
+		node.Value.expiresAt = l.now().Add(ttl).UnixNano()
+	}
+
+	return value, nil
+}
+
+// WithClock sets the time source used for expiration. By default time.Now is used.
+func WithClock[K comparable, V any](now func() time.Time) Option[K, V] {
+	return func(l *cacheImpl[K, V]) {
+		l.clock = now
+	}
+}
+
+func (l *cacheImpl[K, V]) now() time.Time {
+	if l.clock == nil {
+		return time.Now()
+	}
+
+	return l.clock()
+}
+
+// expired reports whether the entry has a deadline that has already passed.
+func (l *cacheImpl[K, V]) expired(node *linkedlist.Node[cacheData[K, V]]) bool {
+	return node.Value.expiresAt != 0 && l.now().UnixNano() >= node.Value.expiresAt
+}
+
+// expireIfNeeded removes the entry if it has expired and reports whether it was removed.
+func (l *cacheImpl[K, V]) expireIfNeeded(node *linkedlist.Node[cacheData[K, V]]) bool {
+	if !l.expired(node) {
+		return false
+	}
+
+	l.remove(node)
+	l.version++
+	l.notifyEvict(node, ReasonExpired)
+
+	return true
+}
+
+// GetWithDeadline behaves like Get and additionally returns the expiration deadline of the entry,
+// the zero time if it does not expire. ok is false if Get would return an error:
+// the key is absent, expired (and removed) or a marker stored by PutNegative.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) GetWithDeadline(key K) (value V, deadline time.Time, ok bool) {
+	value, err := l.Get(key)
+	if err != nil {
+		return value, time.Time{}, false
+	}
+
+	if expiresAt := l.index[key].Value.expiresAt; expiresAt != 0 {
+		deadline = time.Unix(0, expiresAt)
+	}
+
+	return value, deadline, true
+}
+
+// GetAllowStale implements stale-while-revalidate reads. For a live entry it behaves like Get
+// and returns stale = false. For an expired entry it returns the stale value with stale = true
+// and keeps the entry in place: its frequency is not incremented and the read counts as a miss.
+// The caller is expected to serve the stale value and refresh the entry with Put or PutWithTTL,
+// which insert it anew; until then every read keeps reporting it as stale, while Get treats it
+// as absent and removes it.
+// A marker stored by PutNegative is not a value, so it is never served stale: like Get,
+// GetAllowStale returns ErrNegativeCached for a live marker and removes an expired one.
+//
+// Returns ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) GetAllowStale(key K) (value V, stale bool, err error) {
+	node, ok := l.index[key]
+	if !ok || !l.expired(node) || node.Value.negative {
+		value, err = l.Get(key)
+		return value, false, err
+	}
+
+	l.stats.Misses++
+
+	return node.Value.value, true, nil
+}
@@ -0,0 +1,240 @@
+package lfu
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a time source controlled by the test.
+type manualClock struct {
+	mu      sync.Mutex
+	current time.Time
+	tickers []*manualTicker
+}
+
+// manualTicker fires when the clock passes its next deadline. Like time.Ticker, it drops
+// ticks nobody is waiting for.
+type manualTicker struct {
+	ticks    chan time.Time
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{current: time.Unix(1_700_000_000, 0)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current = c.current.Add(d)
+
+	for _, ticker := range c.tickers {
+		for !ticker.stopped && !ticker.next.After(c.current) {
+			select {
+			case ticker.ticks <- ticker.next:
+			default:
+			}
+
+			ticker.next = ticker.next.Add(ticker.interval)
+		}
+	}
+}
+
+// Ticker has the signature of cacheImpl.maintenanceTicker.
+func (c *manualClock) Ticker(interval time.Duration) (<-chan time.Time, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ticker := &manualTicker{
+		ticks:    make(chan time.Time, 1),
+		interval: interval,
+		next:     c.current.Add(interval),
+	}
+	c.tickers = append(c.tickers, ticker)
+
+	return ticker.ticks, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		ticker.stopped = true
+	}
+}
+
+func TestPutWithTTLExpires(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Second)
+	cache.Put(2, 20)
+
+	clock.Advance(999 * time.Millisecond)
+	value, err := cache.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, value)
+
+	clock.Advance(time.Millisecond)
+	_, err = cache.Get(1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, 1, cache.Size())
+
+	clock.Advance(time.Hour)
+	_, err = cache.Get(2)
+	require.NoError(t, err)
+}
+
+func TestPutClearsTTL(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Second)
+	cache.Put(1, 11)
+
+	clock.Advance(time.Minute)
+	value, err := cache.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, 11, value)
+}
+
+func TestPutOverExpiredEntryInsertsAnew(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Second)
+	for range 5 {
+		_, _ = cache.Get(1)
+	}
+
+	clock.Advance(time.Minute)
+	cache.Put(1, 11)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, frequency)
+
+	hits, err := cache.HitCount(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, hits)
+}
+
+func TestGetRefreshingKeepsEntryAlive(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Second)
+	cache.PutWithTTL(2, 20, time.Second)
+
+	for range 5 {
+		clock.Advance(800 * time.Millisecond)
+
+		value, err := cache.GetRefreshing(1, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 10, value)
+	}
+
+	_, err := cache.Get(2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 6, frequency)
+
+	clock.Advance(time.Second)
+	_, err = cache.GetRefreshing(1, time.Second)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestGetAllowStale(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Second)
+
+	value, stale, err := cache.GetAllowStale(1)
+	require.NoError(t, err)
+	require.False(t, stale)
+	require.Equal(t, 10, value)
+
+	clock.Advance(2 * time.Second)
+
+	for range 2 {
+		value, stale, err = cache.GetAllowStale(1)
+		require.NoError(t, err)
+		require.True(t, stale)
+		require.Equal(t, 10, value)
+	}
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+
+	cache.PutWithTTL(1, 11, time.Second)
+
+	value, stale, err = cache.GetAllowStale(1)
+	require.NoError(t, err)
+	require.False(t, stale)
+	require.Equal(t, 11, value)
+
+	_, stale, err = cache.GetAllowStale(2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, stale)
+
+	require.Equal(t, Stats{Hits: 2, Misses: 3}, cache.Stats())
+}
+
+func TestGetWithDeadline(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(3, WithClock[int, int](clock.Now))
+
+	cache.PutWithTTL(1, 10, time.Minute)
+	cache.Put(2, 20)
+	deadline := clock.Now().Add(time.Minute)
+
+	value, actual, ok := cache.GetWithDeadline(1)
+	require.True(t, ok)
+	require.Equal(t, 10, value)
+	require.True(t, deadline.Equal(actual), "expected %v, got %v", deadline, actual)
+
+	frequency, err := cache.GetKeyFrequency(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+
+	value, actual, ok = cache.GetWithDeadline(2)
+	require.True(t, ok)
+	require.Equal(t, 20, value)
+	require.True(t, actual.IsZero())
+
+	_, _, ok = cache.GetWithDeadline(3)
+	require.False(t, ok)
+
+	clock.Advance(time.Minute)
+
+	_, actual, ok = cache.GetWithDeadline(1)
+	require.False(t, ok)
+	require.True(t, actual.IsZero())
+	require.Equal(t, 1, cache.Size())
+}
@@ -0,0 +1,60 @@
+package lfu
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBrokenBackPointer     = errors.New("entry points to a wrong container or index node")
+	ErrNonAscendingFrequency = errors.New("container frequencies are not strictly ascending from 1")
+	ErrEmptyNonRootContainer = errors.New("empty container other than frequency 1")
+	ErrSizeMismatch          = errors.New("number of entries does not match the index size")
+)
+
+// Verify checks the internal invariants of the cache and returns the first violation found,
+// wrapping one of ErrBrokenBackPointer, ErrNonAscendingFrequency, ErrEmptyNonRootContainer
+// or ErrSizeMismatch with details, or nil if the cache is consistent.
+// A violation means a bug in the cache or a data race, not a misuse of the API.
+//
+// O(size)
+func (l *cacheImpl[K, V]) Verify() error {
+	root := l.sequence.Head()
+	if root == nil || root.Value.freq != 1 {
+		return fmt.Errorf("%w: missing frequency 1 container", ErrNonAscendingFrequency)
+	}
+
+	entries := 0
+
+	for container := root; container != nil; container = container.Next() {
+		if next := container.Next(); next != nil && next.Value.freq <= container.Value.freq {
+			return fmt.Errorf("%w: frequency %d followed by %d", ErrNonAscendingFrequency, container.Value.freq, next.Value.freq)
+		}
+
+		if container != root && container.Value.entries.Len() == 0 {
+			return fmt.Errorf("%w: frequency %d", ErrEmptyNonRootContainer, container.Value.freq)
+		}
+
+		for node := container.Value.entries.Head(); node != nil; node = node.Next() {
+			entries++
+
+			if node.Value.container != container {
+				return fmt.Errorf("%w: key %v in container of frequency %d", ErrBrokenBackPointer, node.Value.key, container.Value.freq)
+			}
+
+			if l.index[node.Value.key] != node {
+				return fmt.Errorf("%w: key %v of frequency %d is not indexed", ErrBrokenBackPointer, node.Value.key, container.Value.freq)
+			}
+		}
+	}
+
+	if entries != len(l.index) {
+		return fmt.Errorf("%w: %d entries, %d indexed", ErrSizeMismatch, entries, len(l.index))
+	}
+
+	if l.recency != nil && l.recency.Len() != entries {
+		return fmt.Errorf("%w: %d entries, %d in recency list", ErrSizeMismatch, entries, l.recency.Len())
+	}
+
+	return nil
+}
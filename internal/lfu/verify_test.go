@@ -0,0 +1,80 @@
+package lfu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newVerifyFixture() *cacheImpl[int, int] {
+	cache := NewWithOptions(4, WithGlobalRecency[int, int]())
+
+	for i := 1; i <= 4; i++ {
+		cache.Put(i, i)
+
+		for range i - 1 {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	return cache
+}
+
+func TestVerifyConsistent(t *testing.T) {
+	t.Parallel()
+
+	cache := newVerifyFixture()
+	require.NoError(t, cache.Verify())
+
+	_ = cache.Remove(1)
+	cache.Put(5, 5)
+	cache.Rerank(func(_ int, _ int, freq int) int { return 10 - freq })
+	require.NoError(t, cache.Verify())
+
+	require.NoError(t, New[int, int](0).Verify())
+}
+
+func TestVerifyDetectsCorruption(t *testing.T) {
+	t.Parallel()
+
+	t.Run("broken back pointer", func(t *testing.T) {
+		cache := newVerifyFixture()
+		cache.index[2].Value.container = cache.sequence.Head()
+
+		require.ErrorIs(t, cache.Verify(), ErrBrokenBackPointer)
+	})
+
+	t.Run("stale index", func(t *testing.T) {
+		cache := newVerifyFixture()
+		cache.index[2] = cache.index[3]
+
+		require.ErrorIs(t, cache.Verify(), ErrBrokenBackPointer)
+	})
+
+	t.Run("non ascending frequency", func(t *testing.T) {
+		cache := newVerifyFixture()
+		cache.sequence.Tail().Value.freq = 2
+
+		err := cache.Verify()
+		require.ErrorIs(t, err, ErrNonAscendingFrequency)
+		require.ErrorContains(t, err, "frequency 3 followed by 2")
+	})
+
+	t.Run("empty non root container", func(t *testing.T) {
+		cache := newVerifyFixture()
+		cache.sequence.PushBack(sameFreqContainer[int, int]{freq: 100})
+
+		err := cache.Verify()
+		require.ErrorIs(t, err, ErrEmptyNonRootContainer)
+		require.ErrorContains(t, err, "frequency 100")
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		cache := newVerifyFixture()
+		node := cache.index[4]
+		node.Value.container.Value.entries.Remove(node)
+		cache.sequence.Remove(node.Value.container)
+
+		require.ErrorIs(t, cache.Verify(), ErrSizeMismatch)
+	})
+}
@@ -0,0 +1,80 @@
+package lfu
+
+import "lfucache/internal/linkedlist"
+
+// Version returns the current cache version. It starts at zero and is incremented
+// by every write: inserting or updating a key (Put and its variants, Increment, Rekey)
+// and removing it, whether by Remove, eviction or expiration.
+// Reads and frequency changes do not change it.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) Version() uint64 {
+	return l.version
+}
+
+// ChangedSince returns the entries written after the cache had the given version,
+// in the same order as All. Removed keys are not reported.
+//
+// O(size)
+func (l *cacheImpl[K, V]) ChangedSince(version uint64) []Entry[K, V] {
+	changed := make([]Entry[K, V], 0)
+
+	for node := l.first(); node != nil; node = l.successor(node) {
+		if node.Value.version > version {
+			changed = append(changed, Entry[K, V]{
+				Key:       node.Value.key,
+				Value:     node.Value.value,
+				Frequency: node.Value.container.Value.freq,
+			})
+		}
+	}
+
+	return changed
+}
+
+// stamp marks the entry as written in the next version.
+func (l *cacheImpl[K, V]) stamp(node *linkedlist.Node[cacheData[K, V]]) {
+	l.version++
+	node.Value.version = l.version
+}
+
+// GetWithToken behaves like Get and additionally returns the token of the entry for PutIfToken.
+// The token changes on every write of the key, including removing and inserting it again.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) GetWithToken(key K) (V, uint64, error) {
+	value, err := l.Get(key)
+	if err != nil {
+		return value, 0, err
+	}
+
+	return value, l.index[key].Value.version, nil
+}
+
+// PutIfToken updates the value of the key only if its token still equals the one
+// returned by GetWithToken, implementing optimistic concurrency. On success the write
+// counts as an access, like Put, and the key gets a new token. Under WithDedupeWrites
+// writing an equal value leaves the entry and its frequency as they are, but still
+// issues a new token, so a successful PutIfToken always invalidates the old one.
+//
+// Returns false if the token does not match and ErrKeyNotFound if the key is not present.
+//
+// O(1), not amortized
+func (l *cacheImpl[K, V]) PutIfToken(key K, value V, token uint64) (bool, error) {
+	node, ok := l.index[key]
+	if !ok || l.expireIfNeeded(node) {
+		return false, ErrKeyNotFound
+	}
+
+	if node.Value.version != token {
+		return false, nil
+	}
+
+	l.Put(key, value)
+
+	if node.Value.version == token {
+		l.stamp(node)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,130 @@
+package lfu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedSince(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](4)
+	require.Equal(t, uint64(0), cache.Version())
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	version := cache.Version()
+	require.Equal(t, uint64(3), version)
+
+	_, _ = cache.Get("a")
+	require.Equal(t, version, cache.Version())
+	require.Empty(t, cache.ChangedSince(version))
+
+	cache.Put("b", 20)
+	cache.Put("d", 4)
+
+	require.Equal(t, []Entry[string, int]{
+		{Key: "b", Value: 20, Frequency: 2},
+		{Key: "d", Value: 4, Frequency: 1},
+	}, cache.ChangedSince(version))
+
+	require.NoError(t, cache.Remove("d"))
+	require.Equal(t, version+3, cache.Version())
+	require.Equal(t, []Entry[string, int]{
+		{Key: "b", Value: 20, Frequency: 2},
+	}, cache.ChangedSince(version))
+
+	require.Len(t, cache.ChangedSince(0), 3)
+}
+
+func TestPutIfToken(t *testing.T) {
+	t.Parallel()
+
+	cache := New[string, int](2)
+	cache.Put("a", 1)
+
+	value, token, err := cache.GetWithToken("a")
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	ok, err := cache.PutIfToken("a", 2, token)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	value, newToken, err := cache.GetWithToken("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+	require.Greater(t, newToken, token)
+
+	ok, err = cache.PutIfToken("a", 3, token)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cache.Put("a", 4)
+
+	_, latestToken, err := cache.GetWithToken("a")
+	require.NoError(t, err)
+	require.Greater(t, latestToken, newToken)
+
+	ok, err = cache.PutIfToken("a", 5, newToken)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	value, err = cache.Get("a")
+	require.NoError(t, err)
+	require.Equal(t, 4, value)
+
+	ok, err = cache.PutIfToken("b", 1, 0)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.False(t, ok)
+
+	_, _, err = cache.GetWithToken("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestPutIfTokenWithDedupeWrites(t *testing.T) {
+	t.Parallel()
+
+	cache := NewWithOptions(2, WithDedupeWrites[string, int]())
+	cache.Put("a", 1)
+
+	_, token, err := cache.GetWithToken("a")
+	require.NoError(t, err)
+
+	ok, err := cache.PutIfToken("a", 1, token)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = cache.PutIfToken("a", 1, token)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	frequency, err := cache.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+}
+
+func TestVersionCountsEvictionsAndExpirations(t *testing.T) {
+	t.Parallel()
+
+	clock := newManualClock()
+	cache := NewWithOptions(2, WithClock[string, int](clock.Now))
+
+	cache.Put("a", 1)
+	cache.PutWithTTL("b", 2, time.Minute)
+
+	version := cache.Version()
+
+	cache.Put("c", 3)
+	require.Equal(t, version+2, cache.Version())
+
+	clock.Advance(time.Hour)
+
+	_, err := cache.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.Equal(t, version+3, cache.Version())
+}
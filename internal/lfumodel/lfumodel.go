@@ -0,0 +1,147 @@
+// Package lfumodel provides a brute-force reference LFU cache.
+//
+// The model favours obviousness over speed: every operation scans all entries.
+// It is meant to check the real cache against, not to be used as a cache.
+package lfumodel
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrKeyNotFound is returned by Get and Remove for absent keys.
+var ErrKeyNotFound = errors.New("key not found")
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	freq     int
+	lastUsed int
+}
+
+// Model is the reference LFU cache: the least frequently used entry is evicted,
+// ties are broken by evicting the least recently used one.
+type Model[K comparable, V any] struct {
+	capacity int
+	entries  []entry[K, V]
+	clock    int
+}
+
+// New returns an empty model with the given capacity.
+func New[K comparable, V any](capacity int) *Model[K, V] {
+	return &Model[K, V]{capacity: capacity}
+}
+
+// Get returns the value of the key and counts an access, or returns ErrKeyNotFound.
+func (m *Model[K, V]) Get(key K) (V, error) {
+	i := m.find(key)
+	if i < 0 {
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	m.use(i)
+
+	return m.entries[i].value, nil
+}
+
+// Put updates the value of the key counting an access, or inserts the key
+// evicting the least frequently used entry if the model is full.
+func (m *Model[K, V]) Put(key K, value V) {
+	if i := m.find(key); i >= 0 {
+		m.entries[i].value = value
+		m.use(i)
+
+		return
+	}
+
+	if m.capacity == 0 {
+		return
+	}
+
+	if len(m.entries) >= m.capacity {
+		victim := 0
+		for i, e := range m.entries {
+			if e.freq < m.entries[victim].freq ||
+				e.freq == m.entries[victim].freq && e.lastUsed < m.entries[victim].lastUsed {
+				victim = i
+			}
+		}
+
+		m.entries = slices.Delete(m.entries, victim, victim+1)
+	}
+
+	m.entries = append(m.entries, entry[K, V]{key: key, value: value})
+	m.use(len(m.entries) - 1)
+}
+
+// Peek returns the value of the key without counting an access, or returns ErrKeyNotFound.
+func (m *Model[K, V]) Peek(key K) (V, error) {
+	i := m.find(key)
+	if i < 0 {
+		var zero V
+		return zero, ErrKeyNotFound
+	}
+
+	return m.entries[i].value, nil
+}
+
+// Remove deletes the key or returns ErrKeyNotFound.
+func (m *Model[K, V]) Remove(key K) error {
+	i := m.find(key)
+	if i < 0 {
+		return ErrKeyNotFound
+	}
+
+	m.entries = slices.Delete(m.entries, i, i+1)
+
+	return nil
+}
+
+// GetKeyFrequency returns the number of accesses of the key including its insertion,
+// or ErrKeyNotFound.
+func (m *Model[K, V]) GetKeyFrequency(key K) (int, error) {
+	i := m.find(key)
+	if i < 0 {
+		return 0, ErrKeyNotFound
+	}
+
+	return m.entries[i].freq, nil
+}
+
+// Size returns the number of entries.
+func (m *Model[K, V]) Size() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys ordered from the hottest to the coldest: descending frequency,
+// the most recently used first within a frequency.
+func (m *Model[K, V]) Keys() []K {
+	sorted := slices.Clone(m.entries)
+	slices.SortFunc(sorted, func(a, b entry[K, V]) int {
+		if a.freq != b.freq {
+			return b.freq - a.freq
+		}
+
+		return b.lastUsed - a.lastUsed
+	})
+
+	keys := make([]K, 0, len(sorted))
+	for _, e := range sorted {
+		keys = append(keys, e.key)
+	}
+
+	return keys
+}
+
+func (m *Model[K, V]) find(key K) int {
+	return slices.IndexFunc(m.entries, func(e entry[K, V]) bool {
+		return e.key == key
+	})
+}
+
+func (m *Model[K, V]) use(i int) {
+	m.clock++
+	m.entries[i].freq++
+	m.entries[i].lastUsed = m.clock
+}
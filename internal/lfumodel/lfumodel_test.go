@@ -0,0 +1,40 @@
+package lfumodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelEviction(t *testing.T) {
+	t.Parallel()
+
+	model := New[string, int](2)
+
+	model.Put("a", 1)
+	model.Put("b", 2)
+	_, _ = model.Get("a")
+	model.Put("c", 3)
+
+	require.Equal(t, []string{"a", "c"}, model.Keys())
+
+	_, err := model.Get("b")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	frequency, err := model.GetKeyFrequency("a")
+	require.NoError(t, err)
+	require.Equal(t, 2, frequency)
+
+	require.NoError(t, model.Remove("a"))
+	require.ErrorIs(t, model.Remove("a"), ErrKeyNotFound)
+	require.Equal(t, 1, model.Size())
+}
+
+func TestModelZeroCapacity(t *testing.T) {
+	t.Parallel()
+
+	model := New[string, int](0)
+	model.Put("a", 1)
+
+	require.Zero(t, model.Size())
+}
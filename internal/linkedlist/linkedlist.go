@@ -0,0 +1,206 @@
+package linkedlist
+
+import "iter"
+
+// Node is an element of the List.
+// Nodes can be detached from one list and attached to another
+// without reallocation.
+type Node[T any] struct {
+	Value T
+
+	prev *Node[T]
+	next *Node[T]
+}
+
+// Next returns the next node or nil.
+func (n *Node[T]) Next() *Node[T] {
+	return n.next
+}
+
+// Prev returns the previous node or nil.
+func (n *Node[T]) Prev() *Node[T] {
+	return n.prev
+}
+
+// List represents a doubly linked list.
+// The zero value is an empty list ready to use.
+type List[T any] struct {
+	head *Node[T]
+	tail *Node[T]
+	size int
+}
+
+// New returns an initialized list.
+func New[T any]() *List[T] {
+	return new(List[T])
+}
+
+// Head returns the first node of the list or nil if the list is empty.
+//
+// O(1)
+func (l *List[T]) Head() *Node[T] {
+	return l.head
+}
+
+// Tail returns the last node of the list or nil if the list is empty.
+//
+// O(1)
+func (l *List[T]) Tail() *Node[T] {
+	return l.tail
+}
+
+// Len returns the number of nodes in the list.
+//
+// O(1)
+func (l *List[T]) Len() int {
+	return l.size
+}
+
+// PushBack inserts a new node with the value at the back of the list.
+//
+// O(1)
+func (l *List[T]) PushBack(value T) *Node[T] {
+	node := &Node[T]{Value: value}
+	l.PushBackNode(node)
+
+	return node
+}
+
+// PushFront inserts a new node with the value at the front of the list.
+//
+// O(1)
+func (l *List[T]) PushFront(value T) *Node[T] {
+	node := &Node[T]{Value: value}
+	l.PushFrontNode(node)
+
+	return node
+}
+
+// PushBackNode attaches the detached node at the back of the list.
+//
+// O(1)
+func (l *List[T]) PushBackNode(node *Node[T]) {
+	if l.tail == nil {
+		l.attachFirst(node)
+		return
+	}
+
+	l.attachAfter(node, l.tail)
+}
+
+// PushFrontNode attaches the detached node at the front of the list.
+//
+// O(1)
+func (l *List[T]) PushFrontNode(node *Node[T]) {
+	if l.head == nil {
+		l.attachFirst(node)
+		return
+	}
+
+	l.attachBefore(node, l.head)
+}
+
+// InsertAfter inserts a new node with the value immediately after the mark.
+// The mark must be an element of the list.
+//
+// O(1)
+func (l *List[T]) InsertAfter(value T, mark *Node[T]) *Node[T] {
+	node := &Node[T]{Value: value}
+	l.attachAfter(node, mark)
+
+	return node
+}
+
+// InsertBefore inserts a new node with the value immediately before the mark.
+// The mark must be an element of the list.
+//
+// O(1)
+func (l *List[T]) InsertBefore(value T, mark *Node[T]) *Node[T] {
+	node := &Node[T]{Value: value}
+	l.attachBefore(node, mark)
+
+	return node
+}
+
+// Remove detaches the node from the list.
+// The node must be an element of the list.
+// After removal the node can be attached to any list again.
+//
+// O(1)
+func (l *List[T]) Remove(node *Node[T]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+
+	node.prev = nil
+	node.next = nil
+	l.size--
+}
+
+// All returns the iterator over values from head to tail.
+//
+// O(n)
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.head; node != nil; node = node.next {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns the iterator over values from tail to head.
+//
+// O(n)
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.tail; node != nil; node = node.prev {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+func (l *List[T]) attachFirst(node *Node[T]) {
+	l.head = node
+	l.tail = node
+	l.size++
+}
+
+func (l *List[T]) attachAfter(node, mark *Node[T]) {
+	node.prev = mark
+	node.next = mark.next
+
+	if mark.next != nil {
+		mark.next.prev = node
+	} else {
+		l.tail = node
+	}
+
+	mark.next = node
+	l.size++
+}
+
+func (l *List[T]) attachBefore(node, mark *Node[T]) {
+	node.next = mark
+	node.prev = mark.prev
+
+	if mark.prev != nil {
+		mark.prev.next = node
+	} else {
+		l.head = node
+	}
+
+	mark.prev = node
+	l.size++
+}
@@ -0,0 +1,82 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushAndIterate(t *testing.T) {
+	t.Parallel()
+
+	list := New[int]()
+	list.PushBack(2)
+	list.PushBack(3)
+	list.PushFront(1)
+
+	require.Equal(t, 3, list.Len())
+	require.Equal(t, []int{1, 2, 3}, slices.Collect(list.All()))
+	require.Equal(t, []int{3, 2, 1}, slices.Collect(list.Backward()))
+	require.Equal(t, 1, list.Head().Value)
+	require.Equal(t, 3, list.Tail().Value)
+}
+
+func TestInsertAroundMark(t *testing.T) {
+	t.Parallel()
+
+	list := New[int]()
+	mark := list.PushBack(2)
+	list.InsertBefore(1, mark)
+	list.InsertAfter(3, mark)
+	list.InsertAfter(4, list.Tail())
+
+	require.Equal(t, []int{1, 2, 3, 4}, slices.Collect(list.All()))
+	require.Equal(t, 4, list.Tail().Value)
+}
+
+func TestRemoveAndReattach(t *testing.T) {
+	t.Parallel()
+
+	from := New[int]()
+	to := New[int]()
+
+	first := from.PushBack(1)
+	middle := from.PushBack(2)
+	last := from.PushBack(3)
+
+	from.Remove(middle)
+	require.Equal(t, []int{1, 3}, slices.Collect(from.All()))
+
+	from.Remove(first)
+	from.Remove(last)
+	require.Equal(t, 0, from.Len())
+	require.Nil(t, from.Head())
+	require.Nil(t, from.Tail())
+
+	to.PushBackNode(middle)
+	to.PushFrontNode(first)
+	to.PushBackNode(last)
+	require.Equal(t, []int{1, 2, 3}, slices.Collect(to.All()))
+	require.Equal(t, []int{3, 2, 1}, slices.Collect(to.Backward()))
+}
+
+func TestIteratorStops(t *testing.T) {
+	t.Parallel()
+
+	list := New[int]()
+	for i := range 5 {
+		list.PushBack(i)
+	}
+
+	visited := make([]int, 0)
+	for v := range list.All() {
+		if v == 2 {
+			break
+		}
+
+		visited = append(visited, v)
+	}
+
+	require.Equal(t, []int{0, 1}, visited)
+}